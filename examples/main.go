@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/sgh370/goov/validator"
-	"github.com/sgh370/goov/validator/rules"
 )
 
 // User represents a sample user registration form
@@ -17,36 +16,20 @@ type User struct {
 	ContactMethod string `validate:"required,oneof=email phone"`
 	Website       string `validate:"url,required_if=IsCompany true"`
 	IsCompany     bool
-	IPAddress     string   `validate:"ip"`
+	IPAddress     string   `validate:"omitempty,ip"`
 	Interests     []string `validate:"required,min=1,dive,required"`
 }
 
 func main() {
-	// Create a new validator instance
+	// Create a new validator instance with the default rules registered
 	v := validator.New()
 
-	// Add validation rules
-	v.AddRule("required", rules.Required{})
-	v.AddRule("length:3:20", &rules.Length{Min: 3, Max: 20})
-	v.AddRule("length:8:100", &rules.Length{Min: 8, Max: 100})
-	v.AddRule("phone", &rules.Phone{})
-	v.AddRule("email", &rules.EmailDNS{})
-	v.AddRule("url", &rules.URL{})
-	v.AddRule("ip", &rules.IP{})
-	v.AddRule("oneof", &rules.OneOf{Values: []interface{}{"email", "phone"}})
-
-	// Add conditional rules
-	v.AddRule("required_if", &rules.If{
-		Field: "ContactMethod",
-		Then:  rules.Required{},
-	})
-
 	// Example 1: Valid user
 	validUser := User{
 		Username:      "johndoe",
 		Email:         "john@example.com",
 		Password:      "securepass123",
-		Age:           12,
+		Age:           25,
 		ContactMethod: "email",
 		IsCompany:     false,
 		Interests:     []string{"coding", "reading"},
@@ -70,9 +53,11 @@ func main() {
 		Interests:     []string{},        // empty slice
 	}
 
-	if err := v.Validate(invalidUser); err != nil {
+	if errs := v.ValidateAll(invalidUser); len(errs) > 0 {
 		fmt.Println("\nExpected validation errors:")
-		fmt.Printf("%v\n", err)
+		for _, err := range errs {
+			fmt.Printf("%v\n", err)
+		}
 	}
 
 	// Example 3: Company user with conditional validations
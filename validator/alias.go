@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RegisterAlias registers name as a shorthand for the rules of tag, e.g.
+//
+//	v.RegisterAlias("username", "required,length=3:20,alphanum,lowercase")
+//
+// so that `validate:"username"` validates like the full tag. The tag is
+// resolved when the alias is registered and expands in place wherever the
+// alias is used. A failure reports the failing rule of the alias in
+// FieldError.Rule, e.g. "length", and the alias in FieldError.Alias. Aliases
+// may use other aliases and modifiers such as omitempty, but not dive.
+//
+// The alias is registered as a *Pattern; a Pattern registered with AddRule
+// expands the same way.
+func (v *Validator) RegisterAlias(name, tag string) error {
+	if name == "" || strings.ContainsAny(name, "=:,|' \t") {
+		return fmt.Errorf("invalid alias name %q", name)
+	}
+	switch name {
+	case "omitempty", "omitnil", "dive", "keys", "endkeys", "slice", "message", "groups":
+		return fmt.Errorf("alias %s shadows a tag keyword", name)
+	}
+
+	resolved, err := v.parseTag(tag)
+	if err != nil {
+		return fmt.Errorf("alias %s: %w", name, err)
+	}
+	for _, r := range resolved {
+		if r.name == "dive" {
+			return fmt.Errorf("alias %s: dive cannot be used in an alias", name)
+		}
+	}
+	v.AddRule(name, &Pattern{rules: resolved})
+	return nil
+}
+
+// expandPatterns replaces the rules of a tag that are a *Pattern by the rules
+// of the pattern, recording the tag name of the pattern as their alias. A
+// message= override of the pattern applies to each of its rules.
+func expandPatterns(resolved []tagRule) []tagRule {
+	expanded := resolved[:0:0]
+	for _, r := range resolved {
+		p, ok := r.rule.(*Pattern)
+		if !ok {
+			expanded = append(expanded, r)
+			continue
+		}
+		for _, pr := range p.rules {
+			pr.alias = r.name
+			if r.message != nil {
+				pr.message = r.message
+			}
+			expanded = append(expanded, pr)
+		}
+	}
+	return expanded
+}
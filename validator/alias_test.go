@@ -0,0 +1,143 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+func newAliasValidator(t *testing.T) *Validator {
+	t.Helper()
+	v := New()
+	if err := v.RegisterAlias("username", "required,length=3:20,alphanum,lowercase"); err != nil {
+		t.Fatalf("RegisterAlias() error = %v", err)
+	}
+	if err := v.RegisterAlias("each_username", "username"); err != nil {
+		t.Fatalf("RegisterAlias() error = %v", err)
+	}
+	v.AddRule("contact", NewPattern(rules.Required{}, rules.Length{Min: 6, Max: 50}))
+	return v
+}
+
+func TestRegisterAlias(t *testing.T) {
+	type Username struct {
+		Username string `validate:"username"`
+	}
+	type Nickname struct {
+		Nickname string `validate:"omitempty,username"`
+	}
+
+	tests := []struct {
+		name      string
+		value     interface{}
+		namespace string
+		rule      string
+		param     string
+		alias     string
+	}{
+		{"valid", Username{"gopher"}, "", "", "", ""},
+		{"required", Username{""}, "Username", "required", "", "username"},
+		{"length", Username{"go"}, "Username", "length", "3:20", "username"},
+		{"alphanum", Username{"go-pher"}, "Username", "alphanum", "", "username"},
+		{"lowercase", Username{"Gopher"}, "Username", "lowercase", "", "username"},
+		{"omitempty before alias", Nickname{""}, "", "", "", ""},
+		{"set after omitempty", Nickname{"x"}, "Nickname", "length", "3:20", "username"},
+		{"dive", struct {
+			Handles []string `validate:"dive,username"`
+		}{[]string{"gopher1", "x"}}, "Handles[1]", "length", "3:20", "username"},
+		{"alias of alias as alternative", struct {
+			Backup string `validate:"each_username|email"`
+		}{"gopher"}, "", "", "", ""},
+		{"pattern", struct {
+			Email string `validate:"contact"`
+		}{"a@b"}, "Email", "length", "", "contact"},
+	}
+
+	v := newAliasValidator(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.value)
+			if tt.namespace == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+			var fe *FieldError
+			if !errors.As(err, &fe) {
+				t.Fatalf("Validate() error = %v, want a FieldError", err)
+			}
+			if fe.Namespace != tt.namespace || fe.Rule != tt.rule || fe.Param != tt.param || fe.Alias != tt.alias {
+				t.Errorf("got %q rule %q param %q alias %q, want %q rule %q param %q alias %q",
+					fe.Namespace, fe.Rule, fe.Param, fe.Alias, tt.namespace, tt.rule, tt.param, tt.alias)
+			}
+		})
+	}
+}
+
+func TestRegisterAlias_Errors(t *testing.T) {
+	v := New()
+	tests := []struct {
+		name    string
+		alias   string
+		tag     string
+		wantErr string
+	}{
+		{"empty name", "", "required", "invalid alias name"},
+		{"separator in name", "user,name", "required", "invalid alias name"},
+		{"keyword", "omitempty", "required", "shadows a tag keyword"},
+		{"unknown rule", "username", "required,nosuchrule", "unknown validation rule: nosuchrule"},
+		{"bad parameter", "username", "length=x", "invalid length parameter"},
+		{"dive", "tags", "dive,required", "dive cannot be used in an alias"},
+		{"self reference", "loop", "loop", "unknown validation rule: loop"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.RegisterAlias(tt.alias, tt.tag)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RegisterAlias() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegisterAlias_Messages(t *testing.T) {
+	v := newAliasValidator(t)
+	if err := v.SetMessage("username", "{label} is not a valid {alias} ({rule})"); err != nil {
+		t.Fatal(err)
+	}
+
+	var fe *FieldError
+	err := v.Var("go", "username")
+	if !errors.As(err, &fe) || fe.Message != " is not a valid username (length)" {
+		t.Errorf("Var() error = %v, want the alias message", err)
+	}
+
+	err = v.Var("Gopher", "username,message='pick another name'")
+	if !errors.As(err, &fe) || fe.Message != "pick another name" || fe.Rule != "lowercase" {
+		t.Errorf("Var() error = %v, want the message= override on the lowercase rule", err)
+	}
+
+	// An override of the rule itself takes precedence over the alias.
+	if err := v.SetMessage("required", "{label} is required"); err != nil {
+		t.Fatal(err)
+	}
+	err = v.Validate(struct {
+		Username string `validate:"username"`
+	}{})
+	if !errors.As(err, &fe) || fe.Message != "Username is required" {
+		t.Errorf("Validate() error = %v, want the required override", err)
+	}
+}
+
+func TestRegisterAlias_EachAndSlice(t *testing.T) {
+	v := newAliasValidator(t)
+	if err := v.Var([]string{"gopher", "go"}, "each=username"); err == nil {
+		t.Error("Var() expected an error for the short element")
+	}
+	if err := v.Var([]string{"gopher", "rustacean"}, "each=username"); err != nil {
+		t.Errorf("Var() unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+type benchOrder struct {
+	ID         string      `validate:"required,length=3:32"`
+	CustomerID int         `validate:"min=1"`
+	Status     string      `validate:"oneof=pending paid shipped"`
+	Email      string      `validate:"required,email"`
+	Billing    benchAddr   `validate:"required"`
+	Items      []benchItem `validate:"slice=required"`
+}
+
+type benchAddr struct {
+	Street  string `validate:"required"`
+	City    string `validate:"required,length=2:64"`
+	Country string `validate:"required,length=2"`
+	ZipCode string `validate:"regex=^[0-9]{5}$"`
+}
+
+type benchItem struct {
+	ProductID int     `validate:"min=1"`
+	Quantity  int     `validate:"range=1:100"`
+	UnitPrice float64 `validate:"positive"`
+}
+
+func newBenchOrder() *benchOrder {
+	return &benchOrder{
+		ID:         "order-42",
+		CustomerID: 7,
+		Status:     "paid",
+		Email:      "john@example.com",
+		Billing: benchAddr{
+			Street:  "Main St 1",
+			City:    "Springfield",
+			Country: "US",
+			ZipCode: "12345",
+		},
+		Items: []benchItem{
+			{ProductID: 1, Quantity: 2, UnitPrice: 9.99},
+			{ProductID: 2, Quantity: 1, UnitPrice: 19.99},
+			{ProductID: 3, Quantity: 5, UnitPrice: 4.5},
+		},
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	v := New()
+	order := newBenchOrder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := v.Validate(order); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidate_Parallel(b *testing.B) {
+	v := New()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		order := newBenchOrder()
+		for pb.Next() {
+			if err := v.Validate(order); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkValidate_Invalid(b *testing.B) {
+	v := New()
+	order := newBenchOrder()
+	order.Items[2].Quantity = 0
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := v.Validate(order); err == nil {
+			b.Fatal("expected error")
+		}
+	}
+}
+
+func BenchmarkRules(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		rule  rules.Rule
+		value interface{}
+	}{
+		{"Password", rules.Password{MinLength: 8, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSpecial: true}, "Secret123!"},
+		{"SemVer", rules.SemVer{AllowPrefix: true, AllowPrerelease: true, AllowBuild: true}, "v1.2.3-beta.1+build.5"},
+		{"Domain", rules.Domain{AllowSubdomains: true}, "api.example.com"},
+		{"EmailDNS", rules.EmailDNS{}, "john@example.com"},
+		{"Color", rules.Color{AllowHEX: true, AllowRGB: true, AllowHSL: true}, "hsl(120, 50%, 50%)"},
+		{"MAC", rules.MAC{}, "00:1A:2B:3C:4D:5E"},
+		{"CreditCard", rules.CreditCard{}, "4111 1111 1111 1111"},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := bm.rule.Validate(bm.value); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
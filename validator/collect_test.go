@@ -0,0 +1,142 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+type CollectItem struct {
+	SKU      string `validate:"required"`
+	Quantity int    `validate:"min=1"`
+}
+
+type CollectAddress struct {
+	Street string `validate:"required"`
+	City   string `validate:"required"`
+}
+
+type CollectOrder struct {
+	ID       string        `validate:"required"`
+	Items    []CollectItem `validate:"slice=required"`
+	Billing  CollectAddress
+	Shipping *CollectAddress `validate:"required"`
+}
+
+func invalidOrder() CollectOrder {
+	return CollectOrder{
+		Items: []CollectItem{
+			{SKU: "A1", Quantity: 1},
+			{Quantity: 0},
+		},
+	}
+}
+
+func namespaces(errs []error) []string {
+	var got []string
+	for _, err := range errs {
+		var fe *FieldError
+		if errors.As(err, &fe) {
+			got = append(got, fe.Namespace)
+		} else {
+			got = append(got, err.Error())
+		}
+	}
+	return got
+}
+
+func TestValidateAll_CollectsNestedErrors(t *testing.T) {
+	errs := New().ValidateAll(invalidOrder())
+
+	want := []string{
+		"ID",
+		"Items[1].SKU",
+		"Items[1].Quantity",
+		"Billing.Street",
+		"Billing.City",
+		"Shipping",
+	}
+	got := namespaces(errs)
+	if len(got) != len(want) {
+		t.Fatalf("ValidateAll() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("error %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestValidateAll_MaxErrors(t *testing.T) {
+	v := New()
+	v.SetMaxErrors(2)
+
+	got := namespaces(v.ValidateAll(invalidOrder()))
+	if len(got) != 2 || got[0] != "ID" || got[1] != "Items[1].SKU" {
+		t.Errorf("ValidateAll() = %v, want [ID Items[1].SKU]", got)
+	}
+}
+
+func TestValidate_MatchesFirstCollectedError(t *testing.T) {
+	tests := []struct {
+		name  string
+		value CollectOrder
+	}{
+		{"top level", invalidOrder()},
+		{"slice item", CollectOrder{
+			ID:       "1",
+			Items:    []CollectItem{{SKU: "A1", Quantity: 0}},
+			Billing:  CollectAddress{Street: "Main", City: "Berlin"},
+			Shipping: &CollectAddress{Street: "Main", City: "Berlin"},
+		}},
+		{"untagged nested struct", CollectOrder{
+			ID:       "1",
+			Items:    []CollectItem{{SKU: "A1", Quantity: 1}},
+			Billing:  CollectAddress{Street: "Main"},
+			Shipping: &CollectAddress{Street: "Main", City: "Berlin"},
+		}},
+		{"nested pointer", CollectOrder{
+			ID:       "1",
+			Items:    []CollectItem{{SKU: "A1", Quantity: 1}},
+			Billing:  CollectAddress{Street: "Main", City: "Berlin"},
+			Shipping: &CollectAddress{City: "Berlin"},
+		}},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.value)
+			all := v.ValidateAll(tt.value)
+			if err == nil || len(all) == 0 {
+				t.Fatalf("Validate() = %v, ValidateAll() = %v, want errors", err, all)
+			}
+
+			var verrs ValidationErrors
+			if !errors.As(err, &verrs) || len(verrs) != 1 {
+				t.Fatalf("Validate() error = %v, want one field error", err)
+			}
+			if verrs[0].Error() != all[0].Error() {
+				t.Errorf("Validate() = %q, ValidateAll()[0] = %q", verrs[0], all[0])
+			}
+		})
+	}
+}
+
+func TestValidateAll_ParseErrorOfNestedType(t *testing.T) {
+	type Inner struct {
+		N int `validate:"max=oops"`
+	}
+	type Outer struct {
+		Name  string `validate:"required"`
+		Inner Inner
+	}
+
+	errs := New().ValidateAll(Outer{})
+	if len(errs) != 2 {
+		t.Fatalf("ValidateAll() = %v, want the field error and the parse error", errs)
+	}
+	var fe *FieldError
+	if errors.As(errs[1], &fe) {
+		t.Errorf("ValidateAll()[1] = %v, want a parse error", errs[1])
+	}
+}
@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+type ConcurrentAccount struct {
+	Premium bool
+	Plan    string `validate:"premium_plan"`
+	Limit   int    `validate:"limit"`
+	Backup  string `validate:"backup"`
+}
+
+// TestValidate_SharedContextRules validates many accounts concurrently with
+// the same rule instances. Each result must depend only on its own account;
+// run with -race to check that rules are not mutated during validation.
+func TestValidate_SharedContextRules(t *testing.T) {
+	v := New()
+	v.AddRule("premium_plan", &rules.If{
+		Field: "Premium",
+		Then:  rules.Required{},
+	})
+	v.AddRule("limit", &rules.CrossField{
+		ValidateFn: func(parent, value interface{}) error {
+			if parent.(*ConcurrentAccount).Premium && value.(int) < 100 {
+				return fmt.Errorf("premium limit must be at least 100")
+			}
+			return nil
+		},
+	})
+	v.AddRule("backup", &rules.When{
+		Condition: func(parent interface{}) bool {
+			return parent.(*ConcurrentAccount).Premium
+		},
+		Then: rules.Required{},
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				premium := (g+i)%2 == 0
+				// Premium accounts are valid and basic accounts leave every
+				// field empty, so a basic account validated with a premium
+				// parent would fail.
+				acc := &ConcurrentAccount{Premium: premium}
+				if premium {
+					acc.Plan, acc.Limit, acc.Backup = "gold", 100, "daily"
+				}
+				if err := v.Validate(acc); err != nil {
+					t.Errorf("Validate(premium=%v) error = %v", premium, err)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestValidate_ContextRulePath(t *testing.T) {
+	type Inner struct {
+		Value string `validate:"probe"`
+	}
+	type Outer struct {
+		Inner Inner `validate:"required"`
+	}
+
+	var got rules.ValidationContext
+	v := New()
+	v.AddRule("probe", probeRule{ctx: &got})
+
+	root := &Outer{Inner: Inner{Value: "x"}}
+	if err := v.Validate(root); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if got.Path != "Inner.Value" {
+		t.Errorf("Path = %q, want Inner.Value", got.Path)
+	}
+	if got.Root != root {
+		t.Errorf("Root = %v, want the validated value", got.Root)
+	}
+	if got.Parent != &root.Inner {
+		t.Errorf("Parent = %v, want pointer to Inner", got.Parent)
+	}
+}
+
+type probeRule struct {
+	ctx *rules.ValidationContext
+}
+
+func (p probeRule) Validate(value interface{}) error {
+	return nil
+}
+
+func (p probeRule) ValidateContext(ctx rules.ValidationContext, value interface{}) error {
+	*p.ctx = ctx
+	return nil
+}
@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type CrossShipment struct {
+	Country string `validate:"eqcsfield=Billing.Country"`
+	Carrier string `validate:"nefield=Country"`
+}
+
+type CrossZone struct {
+	Country string
+	Zone    int
+}
+
+func TestValidate_FieldComparisons(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		value     interface{}
+		namespace string
+		rule      string
+	}{
+		{"eqfield", struct {
+			Password        string
+			ConfirmPassword string `validate:"eqfield=Password"`
+		}{"secret", "secrets"}, "ConfirmPassword", "eqfield"},
+		{"ltefield", struct {
+			MinItems int `validate:"ltefield=MaxItems"`
+			MaxItems int
+		}{6, 5}, "MinItems", "ltefield"},
+		{"gtfield dotted path", struct {
+			MaxItems int `validate:"gtfield=Billing.Zone"`
+			Billing  CrossZone
+		}{2, CrossZone{Zone: 2}}, "MaxItems", "gtfield"},
+		{"time", struct {
+			CreatedAt time.Time
+			UpdatedAt time.Time `validate:"gtefield=CreatedAt"`
+		}{created, created.Add(-time.Second)}, "UpdatedAt", "gtefield"},
+		{"time after", struct {
+			CreatedAt time.Time
+			UpdatedAt time.Time `validate:"gtefield=CreatedAt"`
+		}{created, created.Add(time.Hour)}, "", ""},
+		{"duration", struct {
+			Timeout    time.Duration `validate:"ltfield=MaxTimeout"`
+			MaxTimeout time.Duration
+		}{time.Hour, time.Minute}, "Timeout", "ltfield"},
+		{"csfield and nefield in elements", struct {
+			Billing   CrossZone
+			Shipments []CrossShipment
+		}{CrossZone{Country: "DE"}, []CrossShipment{{Country: "DE", Carrier: "DHL"}}}, "", ""},
+		{"eqcsfield from the root", struct {
+			Billing   CrossZone
+			Shipments []CrossShipment
+		}{CrossZone{Country: "DE"}, []CrossShipment{{Country: "FR", Carrier: "DHL"}}}, "Shipments[0].Country", "eqcsfield"},
+		{"nefield in element", struct {
+			Billing   CrossZone
+			Shipments []CrossShipment
+		}{CrossZone{Country: "DE"}, []CrossShipment{{Country: "DE", Carrier: "DE"}}}, "Shipments[0].Carrier", "nefield"},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.value)
+			if tt.rule == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+			var verrs ValidationErrors
+			if !errors.As(err, &verrs) {
+				t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+			}
+			if verrs[0].Namespace != tt.namespace || verrs[0].Rule != tt.rule {
+				t.Errorf("Namespace = %q, Rule = %q, want %q, %q", verrs[0].Namespace, verrs[0].Rule, tt.namespace, tt.rule)
+			}
+		})
+	}
+}
+
+func TestValidate_FieldComparisonErrors(t *testing.T) {
+	v := New()
+
+	err := v.Validate(struct {
+		A string `validate:"eqfield=Missing"`
+	}{})
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Key != "field.missing" {
+		t.Errorf("Validate() error = %v, want a missing field error", err)
+	}
+
+	for _, tag := range []string{"eqcsfield", "eqfield=A..B"} {
+		if err := v.Var("x", tag); err == nil || errors.As(err, &fe) {
+			t.Errorf("Var(%q) error = %v, want a tag error", tag, err)
+		}
+	}
+}
+
+func TestVarWithField_Comparison(t *testing.T) {
+	v := New()
+
+	if err := v.VarWithField("secret", "secret", "eqfield"); err != nil {
+		t.Errorf("VarWithField() unexpected error = %v", err)
+	}
+	var fe *FieldError
+	err := v.VarWithField(5, 10, "gtfield")
+	if !errors.As(err, &fe) || fe.Message != "value must be greater than other value" {
+		t.Errorf("VarWithField() error = %v, want the other value message", err)
+	}
+}
+
+func TestValidateMap_FieldComparison(t *testing.T) {
+	v := New()
+	schema := map[string]interface{}{
+		"password": "required",
+		"confirm":  "eqfield=password",
+		"range": map[string]interface{}{
+			"min": "ltefield=max",
+			"max": "ltecsfield=limits.max",
+		},
+		"limits": map[string]interface{}{},
+	}
+	doc := map[string]interface{}{
+		"password": "secret",
+		"confirm":  "secret",
+		"range":    map[string]interface{}{"min": 1.0, "max": 5.0},
+		"limits":   map[string]interface{}{"max": 10.0},
+	}
+	if err := v.ValidateMap(doc, schema); err != nil {
+		t.Fatalf("ValidateMap() unexpected error = %v", err)
+	}
+
+	doc["range"] = map[string]interface{}{"min": 1.0, "max": 50.0}
+	var fe *FieldError
+	if err := v.ValidateMap(doc, schema); !errors.As(err, &fe) || fe.Namespace != "range.max" {
+		t.Errorf("ValidateMap() error = %v, want an error on range.max", err)
+	}
+}
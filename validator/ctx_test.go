@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+// slowRule blocks until its context is done or delay has passed.
+type slowRule struct {
+	delay time.Duration
+}
+
+func (s slowRule) Validate(value interface{}) error {
+	return s.ValidateCtx(context.Background(), value)
+}
+
+func (s slowRule) ValidateCtx(ctx context.Context, value interface{}) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(s.delay):
+		return nil
+	}
+}
+
+type CtxSignup struct {
+	Name  string `validate:"required"`
+	Email string `validate:"email_dns"`
+}
+
+func TestValidateCtx_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := New().ValidateCtx(ctx, CtxSignup{Name: "john", Email: "john@example.com"})
+
+	var abort *AbortError
+	if !errors.As(err, &abort) {
+		t.Fatalf("ValidateCtx() error = %v, want *AbortError", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ValidateCtx() error = %v, want context.Canceled", err)
+	}
+	var verrs ValidationErrors
+	if errors.As(err, &verrs) {
+		t.Error("ValidateCtx() abort must not be reported as ValidationErrors")
+	}
+}
+
+func TestValidateCtx_Deadline(t *testing.T) {
+	v := New()
+	v.AddRule("slow", slowRule{delay: time.Second})
+
+	type Lookup struct {
+		Host string `validate:"slow"`
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := v.ValidateCtx(ctx, Lookup{Host: "example.com"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ValidateCtx() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("ValidateCtx() took %v, want it bounded by the deadline", elapsed)
+	}
+}
+
+func TestValidateCtx_PlainRulesUnchanged(t *testing.T) {
+	v := New()
+	v.AddRule("slow", slowRule{delay: time.Millisecond})
+	v.AddRule("premium", &rules.If{Field: "Premium", Then: slowRule{delay: time.Millisecond}})
+
+	type Account struct {
+		Premium bool
+		Name    string `validate:"required,slow"`
+		Plan    string `validate:"premium"`
+	}
+
+	if err := v.ValidateCtx(context.Background(), Account{Premium: true, Name: "john"}); err != nil {
+		t.Errorf("ValidateCtx() unexpected error = %v", err)
+	}
+
+	err := v.ValidateCtx(context.Background(), Account{})
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Rule != "required" {
+		t.Errorf("ValidateCtx() error = %v, want required field error", err)
+	}
+}
+
+func TestValidateAllCtx_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errs := New().ValidateAllCtx(ctx, CtxSignup{})
+	if len(errs) != 1 {
+		t.Fatalf("ValidateAllCtx() got %d errors, want 1: %v", len(errs), errs)
+	}
+	if !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("ValidateAllCtx() error = %v, want context.Canceled", errs[0])
+	}
+}
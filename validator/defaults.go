@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+// defaultRules are registered by New under stable tag names. Rules that need
+// a parameter are provided by defaultFactories instead.
+var defaultRules = map[string]rules.Rule{
+	"required":   rules.Required{},
+	"email":      rules.EmailDNS{},
+	"email_dns":  rules.EmailDNS{CheckDNS: true},
+	"json":       rules.JSON{},
+	"uuid":       rules.UUID{},
+	"phone":      rules.Phone{},
+	"ip":         rules.IP{AllowV4: true, AllowV6: true},
+	"ipv4":       rules.IP{AllowV4: true},
+	"ipv6":       rules.IP{AllowV6: true},
+	"cidr":       rules.CIDR{},
+	"mac":        rules.MAC{},
+	"domain":     rules.Domain{AllowSubdomains: true},
+	"hostname":   rules.Hostname{},
+	"latlong":    rules.LatLong{},
+	"color":      rules.Color{AllowHEX: true, AllowRGB: true, AllowHSL: true},
+	"hexcolor":   rules.Color{AllowHEX: true},
+	"creditcard": rules.CreditCard{},
+	"semver":     rules.SemVer{AllowPrefix: true, AllowPrerelease: true, AllowBuild: true},
+	"positive":   rules.Positive{},
+	"negative":   rules.Negative{},
+	"unique":     rules.Unique{},
+	"alphanum":   rules.Alphanum{},
+	"lowercase":  rules.Lowercase{},
+}
+
+func (v *Validator) registerDefaults() {
+	for name, rule := range defaultRules {
+		v.rules[name] = rule
+	}
+	for name, factory := range defaultFactories {
+		v.factories[name] = factory
+	}
+	v.factories["each"] = v.eachFactory
+}
+
+// eachFactory applies the rule named by param to every element, e.g.
+// "each=positive".
+func (v *Validator) eachFactory(param string) (rules.Rule, error) {
+	if param == "" {
+		return nil, fmt.Errorf("expected a rule name")
+	}
+	inner, err := v.resolveNested(param)
+	if err != nil {
+		return nil, err
+	}
+	return rules.Each{Rule: inner.rule}, nil
+}
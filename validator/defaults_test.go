@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestNew_DefaultRules(t *testing.T) {
+	v := New()
+
+	tests := []struct {
+		name    string
+		tag     string
+		value   interface{}
+		wantErr bool
+	}{
+		{"email", "required,email", "john@example.com", false},
+		{"invalid email", "email", "invalid", true},
+		{"disallowed scheme", "url=https", "http://example.com", true},
+		{"ipv6", "ip", "2001:db8::1", false},
+		{"invalid ip", "ip", "256.1.1.1", true},
+		{"invalid uuid", "uuid", "123", true},
+		{"invalid json", "json", "{", true},
+		{"invalid phone", "phone", "12", true},
+		{"semver with prefix", "semver", "v1.2.3-beta", false},
+		{"invalid semver", "semver", "1.2", true},
+		{"invalid color", "hexcolor", "red", true},
+		{"short password", "password=10", "Secret1", true},
+		{"duplicate elements", "unique", []string{"go", "go"}, true},
+		{"missing element", "contains=go", []string{"api"}, true},
+		{"empty element", "each=required", []string{"go", ""}, true},
+		{"positive", "positive", 1, false},
+		{"non-negative", "negative", 0, true},
+		{"alphanum", "alphanum", "go-pher", true},
+		{"lowercase", "lowercase", "Gopher", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Var(tt.value, tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Var(%v, %q) error = %v, wantErr %v", tt.value, tt.tag, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewEmpty(t *testing.T) {
+	type User struct {
+		Name string `validate:"required"`
+	}
+
+	err := NewEmpty().Validate(User{Name: "john"})
+	if err == nil || err.Error() != "Name: unknown validation rule: required" {
+		t.Errorf("Validate() error = %v, want unknown rule error", err)
+	}
+
+	v := NewEmpty()
+	v.AddRuleFactory("max", maxFactory)
+	type Item struct {
+		Count int `validate:"max=3"`
+	}
+	if err := v.Validate(Item{Count: 4}); err == nil {
+		t.Error("Validate() expected error from explicitly added factory")
+	}
+}
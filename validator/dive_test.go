@@ -0,0 +1,166 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+type DiveAddress struct {
+	City string `validate:"required"`
+}
+
+type DiveProfile struct {
+	Interests []string               `validate:"dive,required"`
+	Matrix    [][]string             `validate:"dive,dive,required"`
+	Scores    [3]int                 `validate:"dive,min=0"`
+	Labels    map[string]string      `validate:"dive,keys,length=2:10,endkeys,required"`
+	Addresses []DiveAddress          `validate:"dive"`
+	Contacts  map[string]DiveAddress `validate:"dive,keys,oneof=home work,endkeys"`
+	Optional  []*string              `validate:"omitnil,dive,omitnil,length=3"`
+}
+
+func TestValidate_Dive(t *testing.T) {
+	abc, ab := "abc", "ab"
+
+	tests := []struct {
+		name      string
+		value     interface{}
+		namespace string
+		rule      string
+	}{
+		{"rules before dive", struct {
+			Interests []string `validate:"required,dive,required"`
+		}{}, "Interests", "required"},
+		{"rules before dive pass", struct {
+			Interests []string `validate:"required,dive,required"`
+		}{[]string{"go", ""}}, "Interests[1]", "required"},
+		{"empty element", DiveProfile{Interests: []string{"go", ""}}, "Interests[1]", "required"},
+		{"nested slices", DiveProfile{Matrix: [][]string{{"a"}, {"b", ""}}}, "Matrix[1][1]", "required"},
+		{"nil nested slice", DiveProfile{Matrix: [][]string{nil}}, "", ""},
+		{"array element", DiveProfile{Scores: [3]int{1, 2, -1}}, "Scores[2]", "min"},
+		{"map value", DiveProfile{Labels: map[string]string{"env": ""}}, `Labels["env"]`, "required"},
+		{"map key", DiveProfile{Labels: map[string]string{"e": "prod"}}, `Labels["e"]`, "length"},
+		{"struct element", DiveProfile{Addresses: []DiveAddress{{}, {}}}, "Addresses[0].City", "required"},
+		{"struct map value", DiveProfile{Contacts: map[string]DiveAddress{"work": {}}}, `Contacts["work"].City`, "required"},
+		{"struct map key", DiveProfile{Contacts: map[string]DiveAddress{"other": {City: "Rome"}}}, `Contacts["other"]`, "oneof"},
+		{"nil pointer element", DiveProfile{Optional: []*string{nil, &abc}}, "", ""},
+		{"pointer element", DiveProfile{Optional: []*string{&ab}}, "Optional[0]", "length"},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.value)
+			if (err != nil) != (tt.namespace != "") {
+				t.Fatalf("Validate() error = %v, want an error at %q", err, tt.namespace)
+			}
+			if err == nil {
+				return
+			}
+
+			var verrs ValidationErrors
+			if !errors.As(err, &verrs) {
+				t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+			}
+			if verrs[0].Namespace != tt.namespace || verrs[0].Rule != tt.rule {
+				t.Errorf("Namespace = %q, Rule = %q, want %q, %q", verrs[0].Namespace, verrs[0].Rule, tt.namespace, tt.rule)
+			}
+		})
+	}
+}
+
+func TestValidateAll_DiveCollectsEveryElement(t *testing.T) {
+	p := DiveProfile{
+		Interests: []string{"", "go", ""},
+		Labels:    map[string]string{"bb": "", "aa": "", "cc": "ok"},
+	}
+
+	got := namespaces(New().ValidateAll(p))
+	want := []string{"Interests[0]", "Interests[2]", `Labels["aa"]`, `Labels["bb"]`}
+	if len(got) != len(want) {
+		t.Fatalf("ValidateAll() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("error %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestValidate_DiveMapKeyMessage(t *testing.T) {
+	type Config struct {
+		Env map[string]int `validate:"dive,keys,oneof=dev prod,endkeys,min=1"`
+	}
+
+	err := New().Validate(Config{Env: map[string]int{"test": 1}})
+	want := `Env["test"]: invalid map key: value must be one of: [dev prod]`
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %q", err, want)
+	}
+}
+
+func TestValidate_DiveErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"not a collection", struct {
+			S string `validate:"dive,required"`
+		}{S: "x"}},
+		{"keys on a slice", struct {
+			S []string `validate:"dive,keys,required,endkeys"`
+		}{S: []string{"x"}}},
+		{"keys without dive", struct {
+			M map[string]string `validate:"keys,required,endkeys"`
+		}{}},
+		{"missing endkeys", struct {
+			M map[string]string `validate:"dive,keys,required"`
+		}{}},
+		{"empty keys", struct {
+			M map[string]string `validate:"dive,keys,endkeys"`
+		}{}},
+		{"dive as alternative", struct {
+			S []string `validate:"dive|required"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := New().Validate(tt.value); err == nil {
+				t.Error("Validate() expected an error")
+			}
+		})
+	}
+}
+
+type panicRule struct{}
+
+func (panicRule) Validate(value interface{}) error {
+	panic("boom")
+}
+
+func TestValidate_PanicInKeysDoesNotLeak(t *testing.T) {
+	type Keyed struct {
+		M map[string]string `validate:"dive,keys,boom,endkeys"`
+	}
+	type Plain struct {
+		Name string `validate:"required" label:"Name"`
+	}
+
+	v := New()
+	v.AddRule("boom", panicRule{})
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected the rule to panic")
+			}
+		}()
+		v.Validate(Keyed{M: map[string]string{"k": "v"}})
+	}()
+
+	var fe *FieldError
+	err := v.Validate(Plain{})
+	if !errors.As(err, &fe) || fe.Key != "required" || fe.Message != "value is required" {
+		t.Errorf("Validate() error = %v, want a plain required error", err)
+	}
+}
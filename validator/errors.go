@@ -0,0 +1,145 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+// FieldError describes a single failed rule on a struct field.
+type FieldError struct {
+	// Namespace is the path of the field from the validated value, e.g.
+	// Items[2].Quantity, or /Items/2/Quantity with PathJSONPointer.
+	Namespace string
+	// Field is the name of the field itself, e.g. Quantity or Tags[1].
+	Field string
+	// Label is the human friendly name of the field from its label tag, e.g.
+	// "Shipping ZIP". It defaults to the field name without indices.
+	Label string
+	// Path holds the segments Namespace was rendered from.
+	Path []PathSegment
+	// Rule is the tag name of the failing rule, e.g. "length".
+	Rule string
+	// Param is the tag parameter of the failing rule, e.g. "3:20".
+	Param string
+	// Alias is the tag name of the alias or pattern that Rule belongs to,
+	// e.g. "username" for the length rule of a username alias. It is empty
+	// for rules used directly.
+	Alias string
+	// Value is the value that failed validation.
+	Value interface{}
+	// Message is the human readable reason reported by the rule, in the
+	// locale of the call.
+	Message string
+	// Key and Params identify the message for translation, e.g.
+	// "length.min" with {"min": 3}. Key is empty for rules outside the rules
+	// package that return plain errors.
+	Key    string
+	Params map[string]interface{}
+	// Err is the error returned by the rule. It is a *rules.Error for the
+	// rules of the rules package.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	if e.Namespace == "" {
+		return e.Message
+	}
+	return e.Namespace + ": " + e.Message
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// JSONPointer returns the path of the field as an RFC 6901 JSON Pointer.
+func (e *FieldError) JSONPointer() string {
+	return jsonPointer(e.Path)
+}
+
+// ValidationErrors is returned by Validate when a value fails validation.
+// Use errors.As to retrieve it from the returned error.
+type ValidationErrors []*FieldError
+
+func (ve ValidationErrors) Error() string {
+	msgs := make([]string, len(ve))
+	for i, fe := range ve {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap exposes the individual field errors to errors.Is and errors.As.
+func (ve ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(ve))
+	for i, fe := range ve {
+		errs[i] = fe
+	}
+	return errs
+}
+
+func (v *Validator) newFieldError(path []PathSegment, r tagRule, value reflect.Value, err error) *FieldError {
+	fe := &FieldError{
+		Rule:    r.name,
+		Param:   r.param,
+		Alias:   r.alias,
+		Message: err.Error(),
+		Err:     err,
+	}
+	// Rules may wrap a *rules.Error with context of their own; its key and
+	// params still identify the message.
+	var ruleErr *rules.Error
+	if errors.As(err, &ruleErr) {
+		for _, key := range ruleErr.Path {
+			path = appendPath(path, PathSegment{Key: key})
+		}
+		fe.Message = ruleErr.Message
+		fe.Key, fe.Params = ruleErr.Key, ruleErr.Params
+		if fe.Rule == "" {
+			// Rules of a NewPattern pattern have no tag name of their own.
+			fe.Rule = ruleErr.Rule
+		}
+	}
+	if fe.Rule == "" {
+		fe.Rule = r.alias
+	}
+	fe.Path = path
+	fe.Namespace = formatPath(path, v.pathFormat)
+	fe.Field = fieldName(path)
+	if value.IsValid() && value.CanInterface() {
+		fe.Value = value.Interface()
+	}
+	return fe
+}
+
+// AbortError is returned by ValidateCtx and ValidateAllCtx when the context
+// is done before validation completes. It wraps the context error, so
+// errors.Is(err, context.DeadlineExceeded) works as expected.
+type AbortError struct {
+	Err error
+}
+
+func (e *AbortError) Error() string {
+	return "validation aborted: " + e.Err.Error()
+}
+
+func (e *AbortError) Unwrap() error {
+	return e.Err
+}
+
+// nestedError prefixes errors that are not field errors, such as malformed
+// tags on a nested type, with the name of the field that holds them. Field
+// errors already carry their full namespace.
+func nestedError(name string, err error) error {
+	if err == errStop {
+		return err
+	}
+	switch err.(type) {
+	case *FieldError, *AbortError:
+		return err
+	}
+	return fmt.Errorf("%s: %w", name, err)
+}
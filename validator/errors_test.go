@@ -0,0 +1,129 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+type ErrorsProfile struct {
+	Username string         `validate:"required,length=3:20"`
+	Age      int            `validate:"min=18"`
+	Address  *ErrorsAddress `validate:"required"`
+}
+
+type ErrorsAddress struct {
+	City string `validate:"required"`
+}
+
+func TestValidate_FieldError(t *testing.T) {
+	v := New()
+
+	err := v.Validate(ErrorsProfile{Username: "jo", Age: 20, Address: &ErrorsAddress{City: "Berlin"}})
+
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+	}
+	if len(verrs) != 1 {
+		t.Fatalf("got %d field errors, want 1", len(verrs))
+	}
+
+	fe := verrs[0]
+	if fe.Namespace != "Username" || fe.Field != "Username" {
+		t.Errorf("Namespace = %q, Field = %q, want Username", fe.Namespace, fe.Field)
+	}
+	if fe.Rule != "length" || fe.Param != "3:20" {
+		t.Errorf("Rule = %q, Param = %q, want length 3:20", fe.Rule, fe.Param)
+	}
+	if fe.Value != "jo" {
+		t.Errorf("Value = %v, want jo", fe.Value)
+	}
+	if fe.Message != "length must be at least 3" {
+		t.Errorf("Message = %q", fe.Message)
+	}
+	if err.Error() != "Username: length must be at least 3" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+
+	var ruleErr *rules.Error
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "length" {
+		t.Errorf("errors.As(*rules.Error) = %v, want length rule", ruleErr)
+	}
+}
+
+func TestValidate_NestedFieldError(t *testing.T) {
+	v := New()
+
+	err := v.Validate(&ErrorsProfile{Username: "john", Age: 20, Address: &ErrorsAddress{}})
+
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("Validate() error = %v, want *FieldError", err)
+	}
+	if fe.Namespace != "Address.City" || fe.Field != "City" || fe.Rule != "required" {
+		t.Errorf("got Namespace = %q, Field = %q, Rule = %q", fe.Namespace, fe.Field, fe.Rule)
+	}
+}
+
+func TestValidateAll_FieldErrors(t *testing.T) {
+	v := New()
+
+	errs := v.ValidateAll(ErrorsProfile{Username: "", Age: 10})
+	want := []struct {
+		ns   string
+		rule string
+	}{
+		{"Username", "required"},
+		{"Age", "min"},
+		{"Address", "required"},
+	}
+
+	if len(errs) != len(want) {
+		t.Fatalf("ValidateAll() got %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, w := range want {
+		var fe *FieldError
+		if !errors.As(errs[i], &fe) {
+			t.Fatalf("error %d = %v, want *FieldError", i, errs[i])
+		}
+		if fe.Namespace != w.ns || fe.Rule != w.rule {
+			t.Errorf("error %d: Namespace = %q, Rule = %q, want %q %q", i, fe.Namespace, fe.Rule, w.ns, w.rule)
+		}
+	}
+}
+
+func TestValidationErrors_Error(t *testing.T) {
+	ve := ValidationErrors{
+		{Namespace: "Name", Message: "value is required"},
+		{Namespace: "Age", Message: "value must be greater than or equal to 18"},
+	}
+	want := "Name: value is required; Age: value must be greater than or equal to 18"
+	if ve.Error() != want {
+		t.Errorf("Error() = %q, want %q", ve.Error(), want)
+	}
+}
+
+func TestValidate_WrappedRuleError(t *testing.T) {
+	v := New()
+	v.AddRule("even", rules.Custom{Fn: func(value interface{}) error {
+		if value.(int)%2 != 0 {
+			return fmt.Errorf("odd value: %w", rules.NewError("even", "min", rules.Params{"min": 2}))
+		}
+		return nil
+	}})
+
+	var fe *FieldError
+	err := v.Var(3, "even")
+	if !errors.As(err, &fe) {
+		t.Fatalf("Var() error = %v, want *FieldError", err)
+	}
+	if fe.Rule != "even" || fe.Key != "min" || fe.Params["min"] != 2 {
+		t.Errorf("got Rule = %q, Key = %q, Params = %v, want even min {min: 2}", fe.Rule, fe.Key, fe.Params)
+	}
+	if fe.Message != "value must be greater than or equal to 2" {
+		t.Errorf("Message = %q", fe.Message)
+	}
+}
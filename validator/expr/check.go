@@ -0,0 +1,250 @@
+package expr
+
+import (
+	"reflect"
+	"time"
+)
+
+// kind classifies the values of expressions for type checking.
+type kind int
+
+const (
+	// kindAny is a value whose type is only known at evaluation, such as an
+	// interface or a map element.
+	kindAny kind = iota
+	kindNil
+	kindBool
+	kindNumber
+	kindString
+	kindTime
+	// kindCollection covers slices, arrays and maps.
+	kindCollection
+	kindOther
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindNil:
+		return "nil"
+	case kindBool:
+		return "boolean"
+	case kindNumber:
+		return "number"
+	case kindString:
+		return "string"
+	case kindTime:
+		return "time"
+	case kindCollection:
+		return "collection"
+	case kindOther:
+		return "value"
+	}
+	return "any"
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func kindOfType(t reflect.Type) kind {
+	if t == nil {
+		return kindAny
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return kindTime
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return kindAny
+	case reflect.Bool:
+		return kindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return kindNumber
+	case reflect.String:
+		return kindString
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return kindCollection
+	}
+	return kindOther
+}
+
+// kindOfValue classifies a value produced by Eval.
+func kindOfValue(v interface{}) kind {
+	switch v.(type) {
+	case nil:
+		return kindNil
+	case bool:
+		return kindBool
+	case int64, float64:
+		return kindNumber
+	case string:
+		return kindString
+	case time.Time:
+		return kindTime
+	}
+	return kindOfType(reflect.TypeOf(v))
+}
+
+// checker holds the static types an expression is checked against. A nil
+// type is only known at evaluation.
+type checker struct {
+	env   reflect.Type
+	value reflect.Type
+}
+
+// node is an element of the syntax tree.
+type node interface {
+	check(c *checker) (kind, error)
+	eval(e *evaluator) (interface{}, error)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) check(*checker) (kind, error) {
+	return kindOfValue(n.value), nil
+}
+
+// refNode is a dotted path from the environment, or from the validated value
+// when it starts with the identifier value.
+type refNode struct {
+	pos  int
+	path []string
+}
+
+func (n *refNode) check(c *checker) (kind, error) {
+	t, path := c.env, n.path
+	if path[0] == "value" {
+		t, path = c.value, path[1:]
+	}
+	for _, name := range path {
+		if t == nil {
+			return kindAny, nil
+		}
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			sf, ok := t.FieldByName(name)
+			if !ok || !sf.IsExported() {
+				return 0, errorAt(n.pos, "unknown field %s in %s", name, t)
+			}
+			t = sf.Type
+		case reflect.Map:
+			if t.Key().Kind() != reflect.String {
+				return 0, errorAt(n.pos, "cannot select %s of %s", name, t)
+			}
+			t = t.Elem()
+		case reflect.Interface:
+			return kindAny, nil
+		default:
+			return 0, errorAt(n.pos, "cannot select %s of %s", name, t)
+		}
+	}
+	return kindOfType(t), nil
+}
+
+type unaryNode struct {
+	op      string
+	pos     int
+	operand node
+}
+
+func (n *unaryNode) check(c *checker) (kind, error) {
+	k, err := n.operand.check(c)
+	if err != nil {
+		return 0, err
+	}
+	want := kindNumber
+	if n.op == "!" {
+		want = kindBool
+	}
+	if k != kindAny && k != want {
+		return 0, errorAt(n.pos, "invalid operation: %s%s", n.op, k)
+	}
+	return want, nil
+}
+
+type binaryNode struct {
+	op          string
+	pos         int
+	left, right node
+}
+
+func (n *binaryNode) check(c *checker) (kind, error) {
+	l, err := n.left.check(c)
+	if err != nil {
+		return 0, err
+	}
+	r, err := n.right.check(c)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.op {
+	case "&&", "||":
+		if _, err := n.operands(l, r, kindBool); err != nil {
+			return 0, err
+		}
+		return kindBool, nil
+	case "==", "!=":
+		if l != r && l != kindAny && r != kindAny && l != kindNil && r != kindNil {
+			return 0, errorAt(n.pos, "cannot compare %s with %s", l, r)
+		}
+		return kindBool, nil
+	case "<", "<=", ">", ">=":
+		if _, err := n.operands(l, r, kindNumber, kindString, kindTime); err != nil {
+			return 0, err
+		}
+		return kindBool, nil
+	case "+":
+		return n.operands(l, r, kindNumber, kindString)
+	}
+	return n.operands(l, r, kindNumber)
+}
+
+// operands checks that l and r are of the same kind, one of allowed, and
+// returns it.
+func (n *binaryNode) operands(l, r kind, allowed ...kind) (kind, error) {
+	k := l
+	if l == kindAny {
+		k = r
+	} else if r != kindAny && r != l {
+		return 0, errorAt(n.pos, "invalid operation: %s %s %s", l, n.op, r)
+	}
+	if k == kindAny {
+		return kindAny, nil
+	}
+	for _, a := range allowed {
+		if k == a {
+			return k, nil
+		}
+	}
+	return 0, errorAt(n.pos, "invalid operation: %s %s %s", l, n.op, r)
+}
+
+type callNode struct {
+	fn   string
+	pos  int
+	args []node
+}
+
+func (n *callNode) check(c *checker) (kind, error) {
+	if n.fn == "now" {
+		return kindTime, nil
+	}
+	k, err := n.args[0].check(c)
+	if err != nil {
+		return 0, err
+	}
+	switch k {
+	case kindAny, kindNil, kindString, kindCollection:
+		return kindNumber, nil
+	}
+	return 0, errorAt(n.pos, "invalid argument: len of %s", k)
+}
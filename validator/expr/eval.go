@@ -0,0 +1,298 @@
+package expr
+
+import (
+	"cmp"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// evaluator holds the environment and the validated value of an evaluation.
+type evaluator struct {
+	env   reflect.Value
+	value reflect.Value
+}
+
+func (n *literalNode) eval(*evaluator) (interface{}, error) {
+	return n.value, nil
+}
+
+func (n *refNode) eval(e *evaluator) (interface{}, error) {
+	v, path := e.env, n.path
+	if path[0] == "value" {
+		v, path = e.value, path[1:]
+	} else if !v.IsValid() {
+		return nil, errorAt(n.pos, "no fields to read %s from", strings.Join(path, "."))
+	}
+
+	for _, name := range path {
+		// A nil pointer or a missing map key on the way yields nil.
+		if v = indirect(v); !v.IsValid() {
+			return nil, nil
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			sf, ok := v.Type().FieldByName(name)
+			if !ok || !sf.IsExported() {
+				return nil, errorAt(n.pos, "unknown field %s in %s", name, v.Type())
+			}
+			f, err := v.FieldByIndexErr(sf.Index)
+			if err != nil {
+				return nil, nil
+			}
+			v = f
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return nil, errorAt(n.pos, "cannot select %s of %s", name, v.Type())
+			}
+			v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		default:
+			return nil, errorAt(n.pos, "cannot select %s of %s", name, v.Type())
+		}
+	}
+	return normalize(v), nil
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// normalize converts v to one of the types the operators work on. Nil
+// pointers, slices and maps become nil.
+func normalize(v reflect.Value) interface{} {
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Type() == timeType && v.CanInterface() {
+		return v.Interface().(time.Time)
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := v.Uint(); u <= math.MaxInt64 {
+			return int64(u)
+		}
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+	}
+	if !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func (n *unaryNode) eval(e *evaluator) (interface{}, error) {
+	x, err := n.operand.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	switch x := x.(type) {
+	case bool:
+		if n.op == "!" {
+			return !x, nil
+		}
+	case int64:
+		if n.op == "-" {
+			return -x, nil
+		}
+	case float64:
+		if n.op == "-" {
+			return -x, nil
+		}
+	}
+	return nil, errorAt(n.pos, "invalid operation: %s%s", n.op, kindOfValue(x))
+}
+
+func (n *binaryNode) eval(e *evaluator) (interface{}, error) {
+	l, err := n.left.eval(e)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "&&" || n.op == "||" {
+		lb, ok := l.(bool)
+		if !ok {
+			return nil, errorAt(n.pos, "invalid operation: %s %s", kindOfValue(l), n.op)
+		}
+		// The right operand is only evaluated when it decides the result.
+		if lb == (n.op == "||") {
+			return lb, nil
+		}
+		r, err := n.right.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		rb, ok := r.(bool)
+		if !ok {
+			return nil, errorAt(n.pos, "invalid operation: %s %s", n.op, kindOfValue(r))
+		}
+		return rb, nil
+	}
+
+	r, err := n.right.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==", "!=":
+		eq, ok := equal(l, r)
+		if !ok {
+			return nil, errorAt(n.pos, "cannot compare %s with %s", kindOfValue(l), kindOfValue(r))
+		}
+		return eq == (n.op == "=="), nil
+	case "<", "<=", ">", ">=":
+		order, ok := compare(l, r)
+		if !ok {
+			return nil, errorAt(n.pos, "invalid operation: %s %s %s", kindOfValue(l), n.op, kindOfValue(r))
+		}
+		switch n.op {
+		case "<":
+			return order < 0, nil
+		case "<=":
+			return order <= 0, nil
+		case ">":
+			return order > 0, nil
+		}
+		return order >= 0, nil
+	}
+	return n.arith(l, r)
+}
+
+func (n *binaryNode) arith(l, r interface{}) (interface{}, error) {
+	if ls, ok := l.(string); ok && n.op == "+" {
+		if rs, ok := r.(string); ok {
+			return ls + rs, nil
+		}
+	}
+
+	li, lInt := l.(int64)
+	ri, rInt := r.(int64)
+	if lInt && rInt && n.op != "/" {
+		switch n.op {
+		case "+":
+			return li + ri, nil
+		case "-":
+			return li - ri, nil
+		case "*":
+			return li * ri, nil
+		}
+		if ri == 0 {
+			return nil, errorAt(n.pos, "division by zero")
+		}
+		return li % ri, nil
+	}
+
+	lf, lok := toFloat(l)
+	rf, rok := toFloat(r)
+	if !lok || !rok {
+		return nil, errorAt(n.pos, "invalid operation: %s %s %s", kindOfValue(l), n.op, kindOfValue(r))
+	}
+	switch n.op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	}
+	if rf == 0 {
+		return nil, errorAt(n.pos, "division by zero")
+	}
+	if n.op == "%" {
+		return math.Mod(lf, rf), nil
+	}
+	return lf / rf, nil
+}
+
+func toFloat(x interface{}) (float64, bool) {
+	switch x := x.(type) {
+	case int64:
+		return float64(x), true
+	case float64:
+		return x, true
+	}
+	return 0, false
+}
+
+// equal reports whether l equals r, and false for ok when values of these
+// kinds cannot be compared.
+func equal(l, r interface{}) (eq, ok bool) {
+	if l == nil || r == nil {
+		return l == nil && r == nil, true
+	}
+	if order, ok := compare(l, r); ok {
+		return order == 0, true
+	}
+	switch l := l.(type) {
+	case bool:
+		rb, ok := r.(bool)
+		return ok && l == rb, ok
+	}
+	lk, rk := kindOfValue(l), kindOfValue(r)
+	if lk != rk || lk != kindCollection && lk != kindOther {
+		return false, false
+	}
+	return reflect.DeepEqual(l, r), true
+}
+
+// compare orders numbers, strings and times.
+func compare(l, r interface{}) (int, bool) {
+	switch l := l.(type) {
+	case int64:
+		if ri, ok := r.(int64); ok {
+			return cmp.Compare(l, ri), true
+		}
+	case string:
+		rs, ok := r.(string)
+		return strings.Compare(l, rs), ok
+	case time.Time:
+		rt, ok := r.(time.Time)
+		return l.Compare(rt), ok
+	}
+	lf, lok := toFloat(l)
+	rf, rok := toFloat(r)
+	if !lok || !rok {
+		return 0, false
+	}
+	return cmp.Compare(lf, rf), true
+}
+
+func (n *callNode) eval(e *evaluator) (interface{}, error) {
+	if n.fn == "now" {
+		return time.Now(), nil
+	}
+	x, err := n.args[0].eval(e)
+	if err != nil {
+		return nil, err
+	}
+	switch x := x.(type) {
+	case nil:
+		return int64(0), nil
+	case string:
+		return int64(len(x)), nil
+	}
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return int64(v.Len()), nil
+	}
+	return nil, errorAt(n.pos, "invalid argument: len of %s", kindOfValue(x))
+}
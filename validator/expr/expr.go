@@ -0,0 +1,97 @@
+// Package expr implements the small expression language of the expr
+// validation tag, e.g.
+//
+//	Quantity * UnitPrice == TotalPrice
+//	Age >= 18 && Country == "US"
+//	len(Items) > 0 || Draft
+//
+// Identifiers refer to the fields of the environment, usually the struct
+// holding the validated field, and may be dotted paths through nested
+// structs, pointers and maps with string keys. The identifier value refers to
+// the validated value itself, and true, false and nil are the usual
+// constants.
+//
+// Expressions support integer, float and string literals (in double or
+// single quotes), the arithmetic operators + - * / %, comparisons
+// == != < <= > >=, the boolean operators && || !, and parentheses. Strings
+// concatenate with + and compare lexically; time.Time values compare
+// chronologically. Integer arithmetic stays exact, / always divides as
+// floats, and numbers of any kind compare by value.
+//
+// Two functions are available: len(x), the length of a string, slice, array
+// or map, and now(), the current time.
+//
+// Expressions cannot call methods, index collections or have side effects,
+// so they are safe to take from struct tags.
+package expr
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Program is a parsed expression. It is immutable and safe for concurrent
+// use.
+type Program struct {
+	src  string
+	root node
+}
+
+// Compile parses src.
+func Compile(src string) (*Program, error) {
+	p := parser{lexer: lexer{src: src}}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &Program{src: src, root: root}, nil
+}
+
+// MustCompile is like Compile but panics if src cannot be parsed.
+func MustCompile(src string) *Program {
+	p, err := Compile(src)
+	if err != nil {
+		panic(fmt.Sprintf("expr: Compile(%q): %v", src, err))
+	}
+	return p
+}
+
+// String returns the source of the expression.
+func (p *Program) String() string {
+	return p.src
+}
+
+// Check type-checks the expression against the type of the environment and
+// of value, and verifies that it yields a boolean. Fields are resolved
+// statically as far as the types allow; a nil type, interfaces and map
+// elements are only known when the expression is evaluated.
+func (p *Program) Check(env, value reflect.Type) error {
+	k, err := p.root.check(&checker{env: env, value: value})
+	if err != nil {
+		return err
+	}
+	if k != kindAny && k != kindBool {
+		return fmt.Errorf("expression yields %s, not a boolean", k)
+	}
+	return nil
+}
+
+// Eval evaluates the expression with the fields of env and value as the
+// value identifier. The result is nil, a bool, an int64, a float64, a string,
+// a time.Time, or the value of a field of another type.
+func (p *Program) Eval(env, value interface{}) (interface{}, error) {
+	return p.root.eval(&evaluator{env: reflect.ValueOf(env), value: reflect.ValueOf(&value).Elem()})
+}
+
+// EvalBool is like Eval but requires a boolean result.
+func (p *Program) EvalBool(env, value interface{}) (bool, error) {
+	result, err := p.Eval(env, value)
+	if err != nil {
+		return false, err
+	}
+	b, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression yields %s, not a boolean", kindOfValue(result))
+	}
+	return b, nil
+}
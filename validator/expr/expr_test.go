@@ -0,0 +1,183 @@
+package expr
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type address struct {
+	Country string
+}
+
+type order struct {
+	Quantity   int
+	UnitPrice  float64
+	TotalPrice float64
+	Age        uint8
+	Draft      bool
+	Note       *string
+	Items      []string
+	Address    *address
+	Meta       map[string]interface{}
+	Created    time.Time
+	Any        interface{}
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{name: "arithmetic and comparison", src: "Quantity * UnitPrice == TotalPrice"},
+		{name: "boolean logic", src: `Age >= 18 && Address.Country == "US" || !Draft`},
+		{name: "functions", src: "len(Items) > 0 && Created < now()"},
+		{name: "single quoted string", src: "Address.Country != 'US'"},
+		{name: "parentheses", src: "(Quantity + 1) * 2 > 10"},
+		{name: "empty", src: "  ", wantErr: "empty expression"},
+		{name: "trailing operator", src: "Quantity >", wantErr: "unexpected end of expression"},
+		{name: "unbalanced parenthesis", src: "(Quantity > 1", wantErr: `expected ")"`},
+		{name: "chained comparison", src: "1 < Quantity < 10", wantErr: "cannot be chained"},
+		{name: "unknown function", src: "upper(Address.Country)", wantErr: "unknown function upper"},
+		{name: "wrong arity", src: "len(Items, 1) > 0", wantErr: "len takes 1 argument(s), got 2"},
+		{name: "unterminated string", src: `Address.Country == "US`, wantErr: "unterminated string"},
+		{name: "unexpected character", src: "Quantity # 2", wantErr: `column 10: unexpected '#'`},
+		{name: "dangling dot", src: "Address. > 1", wantErr: "expected field name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.src)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Compile(%q) error = %v", tt.src, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Compile(%q) error = %v, want %q", tt.src, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProgram_Check(t *testing.T) {
+	orderType := reflect.TypeOf(order{})
+	tests := []struct {
+		name    string
+		src     string
+		env     reflect.Type
+		value   reflect.Type
+		wantErr string
+	}{
+		{name: "valid", src: "Quantity * UnitPrice == TotalPrice", env: orderType},
+		{name: "pointer env", src: "Address.Country == 'US'", env: reflect.PtrTo(orderType)},
+		{name: "value identifier", src: "value > Quantity", env: orderType, value: reflect.TypeOf(0)},
+		{name: "nil comparison", src: "Note != nil && Address != nil", env: orderType},
+		{name: "map and interface are dynamic", src: "Meta.channel.name == 1 && Any > 3", env: orderType},
+		{name: "unknown env is dynamic", src: "Missing > 1"},
+		{name: "unknown field", src: "Missing > 1", env: orderType, wantErr: "unknown field Missing in expr.order"},
+		{name: "unknown nested field", src: "Address.City == ''", env: orderType, wantErr: "unknown field City"},
+		{name: "select of scalar", src: "Quantity.Value > 1", env: orderType, wantErr: "cannot select Value of int"},
+		{name: "mismatched comparison", src: "Quantity == 'ten'", env: orderType, wantErr: "cannot compare number with string"},
+		{name: "mismatched arithmetic", src: "Quantity + Address.Country == ''", env: orderType, wantErr: "invalid operation: number + string"},
+		{name: "ordering booleans", src: "Draft < true", env: orderType, wantErr: "invalid operation: boolean < boolean"},
+		{name: "not a number", src: "!Quantity", env: orderType, wantErr: "invalid operation: !number"},
+		{name: "len of number", src: "len(Quantity) > 0", env: orderType, wantErr: "len of number"},
+		{name: "not boolean", src: "Quantity * 2", env: orderType, wantErr: "yields number, not a boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MustCompile(tt.src).Check(tt.env, tt.value)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Check() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Check() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProgram_Eval(t *testing.T) {
+	note := "gift"
+	o := &order{
+		Quantity:   3,
+		UnitPrice:  2.5,
+		TotalPrice: 7.5,
+		Age:        21,
+		Note:       &note,
+		Items:      []string{"a", "b"},
+		Address:    &address{Country: "US"},
+		Meta:       map[string]interface{}{"channel": "web", "weight": 1.5},
+		Created:    time.Now().Add(-time.Hour),
+	}
+
+	tests := []struct {
+		name    string
+		src     string
+		env     interface{}
+		value   interface{}
+		want    interface{}
+		wantErr string
+	}{
+		{name: "computed total", src: "Quantity * UnitPrice == TotalPrice", env: o, want: true},
+		{name: "integer arithmetic", src: "Quantity * 2 + 1", env: o, want: int64(7)},
+		{name: "division is float", src: "Quantity / 2", env: o, want: 1.5},
+		{name: "modulo", src: "Quantity % 2", env: o, want: int64(1)},
+		{name: "negation", src: "-Quantity", env: o, want: int64(-3)},
+		{name: "unsigned and signed", src: "Age >= 18", env: o, want: true},
+		{name: "string concatenation", src: "Address.Country + '-' + Meta.channel", env: o, want: "US-web"},
+		{name: "nested path", src: `Address.Country == "US" && !Draft`, env: o, want: true},
+		{name: "pointer field", src: "Note == 'gift'", env: o, want: true},
+		{name: "map key", src: "Meta.weight > 1", env: o, want: true},
+		{name: "missing map key is nil", src: "Meta.source == nil", env: o, want: true},
+		{name: "nil pointer on the way", src: "Address.Country == nil", env: &order{}, want: true},
+		{name: "len", src: "len(Items) + len(Address.Country) + len(Meta)", env: o, want: int64(6)},
+		{name: "len of nil slice", src: "len(Items) == 0", env: order{}, want: true},
+		{name: "time", src: "Created < now()", env: o, want: true},
+		{name: "value", src: "value == Quantity", env: o, value: 3, want: true},
+		{name: "value without env", src: "len(value) >= 3", value: "abc", want: true},
+		{name: "map env", src: "price * qty", env: map[string]interface{}{"price": 2.0, "qty": 4}, want: 8.0},
+		{name: "short circuit", src: "Draft && 1 / 0 > 1", env: o, want: false},
+		{name: "collections compare deeply", src: "Items == value", env: o, value: []string{"a", "b"}, want: true},
+		{name: "no env", src: "Quantity > 1", wantErr: "no fields to read Quantity from"},
+		{name: "division by zero", src: "Quantity / 0 > 1", env: o, wantErr: "division by zero"},
+		{name: "dynamic type mismatch", src: "Meta.channel > 1", env: o, wantErr: "invalid operation: string > number"},
+		{name: "unknown field", src: "Missing", env: o, wantErr: "unknown field Missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MustCompile(tt.src).Eval(tt.env, tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Eval() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Eval() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Eval() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgram_EvalBool(t *testing.T) {
+	if _, err := MustCompile("1 + 1").EvalBool(nil, nil); err == nil || !strings.Contains(err.Error(), "not a boolean") {
+		t.Errorf("EvalBool() error = %v, want a non-boolean error", err)
+	}
+	ok, err := MustCompile("value != ''").EvalBool(nil, "x")
+	if err != nil || !ok {
+		t.Errorf("EvalBool() = %v, %v, want true", ok, err)
+	}
+}
@@ -0,0 +1,310 @@
+package expr
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokInt
+	tokFloat
+	tokString
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// lexer splits an expression into tokens.
+type lexer struct {
+	src string
+	pos int
+}
+
+// operators lists the operators and punctuation, longest first.
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "+", "-", "*", "/", "%", "!", "(", ")", ",", "."}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) && strings.ContainsRune(" \t\n\r", rune(l.src[l.pos])) {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos == len(l.src) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case isLetter(c):
+		for l.pos < len(l.src) && (isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokIdent, text: l.src[start:l.pos], pos: start}, nil
+	case isDigit(c):
+		kind := tokInt
+		for l.pos < len(l.src) && (isDigit(l.src[l.pos]) || l.src[l.pos] == '.') {
+			if l.src[l.pos] == '.' {
+				if kind == tokFloat {
+					break
+				}
+				kind = tokFloat
+			}
+			l.pos++
+		}
+		return token{kind: kind, text: l.src[start:l.pos], pos: start}, nil
+	case c == '"' || c == '\'':
+		return l.quoted(c)
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.pos += len(op)
+			return token{kind: tokOp, text: op, pos: start}, nil
+		}
+	}
+	return token{}, errorAt(start, "unexpected %q", c)
+}
+
+// quoted reads a string literal. A backslash escapes the quote and itself.
+func (l *lexer) quoted(quote byte) (token, error) {
+	start := l.pos
+	l.pos++
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		l.pos++
+		switch c {
+		case quote:
+			return token{kind: tokString, text: b.String(), pos: start}, nil
+		case '\\':
+			if l.pos < len(l.src) && (l.src[l.pos] == quote || l.src[l.pos] == '\\') {
+				c = l.src[l.pos]
+				l.pos++
+			}
+		}
+		b.WriteByte(c)
+	}
+	return token{}, errorAt(start, "unterminated string")
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func errorAt(pos int, format string, args ...interface{}) error {
+	return fmt.Errorf("column %d: %s", pos+1, fmt.Sprintf(format, args...))
+}
+
+// parser is a recursive descent parser. From the lowest precedence up, the
+// levels are ||, &&, comparisons, + and -, then * / and %, and finally the
+// unary operators.
+type parser struct {
+	lexer lexer
+	tok   token
+}
+
+func (p *parser) parse() (node, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokEOF {
+		return nil, errorAt(p.tok.pos, "empty expression")
+	}
+	n, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, errorAt(p.tok.pos, "unexpected %q", p.tok.text)
+	}
+	return n, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// levels holds the binary operators by increasing precedence.
+var levels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) binary(level int) (node, error) {
+	if level == len(levels) {
+		return p.unary()
+	}
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOp && slices.Contains(levels[level], p.tok.text) {
+		op := p.tok
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op.text, pos: op.pos, left: left, right: right}
+		// Comparisons do not chain: a < b < c is an error.
+		if levels[level][0] == "==" && p.tok.kind == tokOp && slices.Contains(levels[level], p.tok.text) {
+			return nil, errorAt(p.tok.pos, "comparisons cannot be chained")
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (node, error) {
+	if p.tok.kind == tokOp && (p.tok.text == "!" || p.tok.text == "-") {
+		op := p.tok
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: op.text, pos: op.pos, operand: operand}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokInt:
+		i, err := strconv.ParseInt(tok.text, 10, 64)
+		if err != nil {
+			return nil, errorAt(tok.pos, "invalid integer %s", tok.text)
+		}
+		return &literalNode{value: i}, p.advance()
+	case tokFloat:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, errorAt(tok.pos, "invalid number %s", tok.text)
+		}
+		return &literalNode{value: f}, p.advance()
+	case tokString:
+		return &literalNode{value: tok.text}, p.advance()
+	case tokIdent:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokOp && p.tok.text == "(" {
+			return p.call(tok)
+		}
+		switch tok.text {
+		case "true", "false":
+			return &literalNode{value: tok.text == "true"}, nil
+		case "nil":
+			return &literalNode{}, nil
+		}
+		return p.ref(tok)
+	case tokOp:
+		if tok.text == "(" {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			n, err := p.binary(0)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+	case tokEOF:
+		return nil, errorAt(tok.pos, "unexpected end of expression")
+	}
+	return nil, errorAt(tok.pos, "unexpected %q", tok.text)
+}
+
+// ref parses the dotted path starting with the identifier first.
+func (p *parser) ref(first token) (node, error) {
+	n := &refNode{pos: first.pos, path: []string{first.text}}
+	for p.tok.kind == tokOp && p.tok.text == "." {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokIdent {
+			return nil, errorAt(p.tok.pos, "expected field name after '.'")
+		}
+		n.path = append(n.path, p.tok.text)
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// call parses the arguments of the function named by fn.
+func (p *parser) call(fn token) (node, error) {
+	n := &callNode{fn: fn.text, pos: fn.pos}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	for !(p.tok.kind == tokOp && p.tok.text == ")") {
+		if len(n.args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.binary(0)
+		if err != nil {
+			return nil, err
+		}
+		n.args = append(n.args, arg)
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	want, ok := builtins[n.fn]
+	switch {
+	case !ok:
+		return nil, errorAt(fn.pos, "unknown function %s", n.fn)
+	case len(n.args) != want:
+		return nil, errorAt(fn.pos, "%s takes %d argument(s), got %d", n.fn, want, len(n.args))
+	}
+	return n, nil
+}
+
+func (p *parser) expect(op string) error {
+	if p.tok.kind != tokOp || p.tok.text != op {
+		if p.tok.kind == tokEOF {
+			return errorAt(p.tok.pos, "expected %q at end of expression", op)
+		}
+		return errorAt(p.tok.pos, "expected %q, found %q", op, p.tok.text)
+	}
+	return p.advance()
+}
+
+// builtins maps the functions to their number of arguments.
+var builtins = map[string]int{
+	"len": 1,
+	"now": 0,
+}
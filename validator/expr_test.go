@@ -0,0 +1,212 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ExprLine struct {
+	Quantity   int     `validate:"min=1"`
+	UnitPrice  float64 `validate:"min=0"`
+	TotalPrice float64 `validate:"expr=Quantity * UnitPrice == TotalPrice"`
+}
+
+func TestValidate_Expr(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		value     interface{}
+		namespace string
+	}{
+		{"minor with consent", struct {
+			Age     int
+			Consent bool `validate:"expr='Age >= 18 || value'"`
+		}{16, true}, ""},
+		{"minor without consent", struct {
+			Age     int
+			Consent bool `validate:"expr='Age >= 18 || value'"`
+		}{16, false}, "Consent"},
+		{"dive element", struct {
+			Tags   []string `validate:"dive,expr=len(value) <= MaxTag"`
+			MaxTag int
+		}{[]string{"go", "golang"}, 3}, "Tags[1]"},
+		{"time and string", struct {
+			Country   string
+			ExpiresAt time.Time `validate:"expr=value > now() && Country == \"US\""`
+		}{"US", expires}, ""},
+		{"time and other string", struct {
+			Country   string
+			ExpiresAt time.Time `validate:"expr=value > now() && Country == \"US\""`
+		}{"CA", expires}, "ExpiresAt"},
+		{"computed total", struct {
+			Lines []ExprLine
+		}{[]ExprLine{{Quantity: 3, UnitPrice: 2.5, TotalPrice: 7.5}}}, ""},
+		{"wrong computed total", struct {
+			Lines []ExprLine
+		}{[]ExprLine{{Quantity: 3, UnitPrice: 2.5, TotalPrice: 8}}}, "Lines[0].TotalPrice"},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.value)
+			if tt.namespace == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+			var fe *FieldError
+			if !errors.As(err, &fe) {
+				t.Fatalf("Validate() error = %v, want a FieldError", err)
+			}
+			if fe.Namespace != tt.namespace || fe.Rule != "expr" || fe.Key != "expr" {
+				t.Errorf("Namespace = %q, Rule = %q, Key = %q, want %q, expr, expr", fe.Namespace, fe.Rule, fe.Key, tt.namespace)
+			}
+		})
+	}
+}
+
+func TestValidate_ExprCompileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr string
+	}{
+		{
+			name: "syntax",
+			value: struct {
+				A int `validate:"expr=A >"`
+			}{},
+			wantErr: "invalid expr parameter",
+		},
+		{
+			name: "unknown field",
+			value: struct {
+				A int `validate:"expr=B > 1"`
+			}{},
+			wantErr: "unknown field B",
+		},
+		{
+			name: "type mismatch",
+			value: struct {
+				A int `validate:"expr=A == B"`
+				B string
+			}{},
+			wantErr: "cannot compare number with string",
+		},
+		{
+			name: "not boolean",
+			value: struct {
+				A int `validate:"expr=A + 1"`
+			}{},
+			wantErr: "not a boolean",
+		},
+		{
+			name: "dive element type",
+			value: struct {
+				A []int `validate:"dive,expr=len(value) > 0"`
+			}{},
+			wantErr: "len of number",
+		},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.value)
+			var fe *FieldError
+			if err == nil || errors.As(err, &fe) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want a compile error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVar_Expr(t *testing.T) {
+	v := New()
+
+	if err := v.Var(12, "expr=value % 2 == 0"); err != nil {
+		t.Errorf("Var() unexpected error = %v", err)
+	}
+	if err := v.VarWithField(5, 10, "expr=value > 10"); err == nil {
+		t.Error("VarWithField() expected an error for 5 > 10")
+	}
+
+	var fe *FieldError
+	err := v.Var(1, "expr=value / 0 > 1")
+	if !errors.As(err, &fe) || fe.Key != "expr.eval" || !strings.Contains(fe.Message, "division by zero") {
+		t.Errorf("Var() error = %v, want an evaluation error", err)
+	}
+}
+
+func TestValidateMap_Expr(t *testing.T) {
+	v := New()
+	schema := map[string]interface{}{
+		"quantity": "required",
+		"price":    "required",
+		"total":    "expr=quantity * price == total",
+	}
+	doc := map[string]interface{}{"quantity": 2.0, "price": 4.5, "total": 9.0}
+	if err := v.ValidateMap(doc, schema); err != nil {
+		t.Fatalf("ValidateMap() unexpected error = %v", err)
+	}
+
+	doc["total"] = 10.0
+	var fe *FieldError
+	if err := v.ValidateMap(doc, schema); !errors.As(err, &fe) || fe.Namespace != "total" {
+		t.Errorf("ValidateMap() error = %v, want an error on total", err)
+	}
+}
+
+func TestVar_ExprCompileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(v *Validator) error
+		wantErr  string
+	}{
+		{
+			name:     "var not boolean",
+			validate: func(v *Validator) error { return v.Var(1, "expr=len(value) + 1") },
+			wantErr:  "not a boolean",
+		},
+		{
+			name:     "var type mismatch",
+			validate: func(v *Validator) error { return v.Var(1, "expr=len(value) == true") },
+			wantErr:  "cannot compare number with bool",
+		},
+		{
+			name:     "var with field not boolean",
+			validate: func(v *Validator) error { return v.VarWithField(1, 2, "expr=value * 2") },
+			wantErr:  "not a boolean",
+		},
+		{
+			name: "map not boolean",
+			validate: func(v *Validator) error {
+				return v.ValidateMap(map[string]interface{}{}, map[string]interface{}{"total": "expr=len(total) + 1"})
+			},
+			wantErr: "not a boolean",
+		},
+		{
+			name: "map dive not boolean",
+			validate: func(v *Validator) error {
+				return v.ValidateMap(map[string]interface{}{}, map[string]interface{}{"items": "dive,expr=len(value) * 2"})
+			},
+			wantErr: "not a boolean",
+		},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate(v)
+			var fe *FieldError
+			if err == nil || errors.As(err, &fe) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want a compile error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
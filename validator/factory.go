@@ -0,0 +1,288 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sgh370/goov/validator/expr"
+	"github.com/sgh370/goov/validator/rules"
+)
+
+// RuleFactory builds a rule from the parameter of a tag such as "max=10" or
+// "oneof=a b c". Factories run once per tag when a struct type is first
+// validated, so a malformed parameter is reported before any value is checked.
+type RuleFactory func(param string) (rules.Rule, error)
+
+// AddRuleFactory binds name to a factory so tags of the form name=param
+// resolve without registering every parameter combination with AddRule.
+func (v *Validator) AddRuleFactory(name string, factory RuleFactory) {
+	v.factories[name] = factory
+	v.resetPlans()
+}
+
+var defaultFactories = map[string]RuleFactory{
+	"min":      minFactory,
+	"max":      maxFactory,
+	"len":      lengthFactory,
+	"length":   lengthFactory,
+	"range":    rangeFactory,
+	"oneof":    oneOfFactory,
+	"regex":    regexFactory,
+	"port":     portFactory,
+	"date":     dateFactory,
+	"time":     timeFactory,
+	"url":      urlFactory,
+	"password": passwordFactory,
+	"contains": containsFactory,
+	"expr":     exprFactory,
+
+	"eqfield":    fieldFactory(rules.OpEq, false),
+	"nefield":    fieldFactory(rules.OpNe, false),
+	"gtfield":    fieldFactory(rules.OpGt, false),
+	"gtefield":   fieldFactory(rules.OpGte, false),
+	"ltfield":    fieldFactory(rules.OpLt, false),
+	"ltefield":   fieldFactory(rules.OpLte, false),
+	"eqcsfield":  fieldFactory(rules.OpEq, true),
+	"necsfield":  fieldFactory(rules.OpNe, true),
+	"gtcsfield":  fieldFactory(rules.OpGt, true),
+	"gtecsfield": fieldFactory(rules.OpGte, true),
+	"ltcsfield":  fieldFactory(rules.OpLt, true),
+	"ltecsfield": fieldFactory(rules.OpLte, true),
+
+	"required_if":          presenceFactory(false, rules.WhenIf),
+	"required_unless":      presenceFactory(false, rules.WhenUnless),
+	"required_with":        presenceFactory(false, rules.WhenWith),
+	"required_with_all":    presenceFactory(false, rules.WhenWithAll),
+	"required_without":     presenceFactory(false, rules.WhenWithout),
+	"required_without_all": presenceFactory(false, rules.WhenWithoutAll),
+	"excluded_if":          presenceFactory(true, rules.WhenIf),
+	"excluded_unless":      presenceFactory(true, rules.WhenUnless),
+	"excluded_with":        presenceFactory(true, rules.WhenWith),
+	"excluded_with_all":    presenceFactory(true, rules.WhenWithAll),
+	"excluded_without":     presenceFactory(true, rules.WhenWithout),
+	"excluded_without_all": presenceFactory(true, rules.WhenWithoutAll),
+}
+
+func minFactory(param string) (rules.Rule, error) {
+	val, err := parseFloat(param)
+	if err != nil {
+		return nil, err
+	}
+	return rules.Min{Value: val}, nil
+}
+
+func maxFactory(param string) (rules.Rule, error) {
+	val, err := parseFloat(param)
+	if err != nil {
+		return nil, err
+	}
+	return rules.Max{Value: val}, nil
+}
+
+// lengthFactory accepts an exact length ("5") or a bounded one ("3:20"). It
+// backs both len and length.
+func lengthFactory(param string) (rules.Rule, error) {
+	lo, hi, hasHi := strings.Cut(param, ":")
+	min, err := parseInt(lo)
+	if err != nil {
+		return nil, err
+	}
+	if !hasHi {
+		if min < 0 {
+			return nil, fmt.Errorf("length %d is negative", min)
+		}
+		return rules.ExactLength{Value: min}, nil
+	}
+	max, err := parseInt(hi)
+	if err != nil {
+		return nil, err
+	}
+	if max > 0 && max < min {
+		return nil, fmt.Errorf("max %d is less than min %d", max, min)
+	}
+	return rules.Length{Min: min, Max: max}, nil
+}
+
+// rangeFactory accepts "min:max".
+func rangeFactory(param string) (rules.Rule, error) {
+	lo, hi, ok := strings.Cut(param, ":")
+	if !ok {
+		return nil, fmt.Errorf("expected min:max")
+	}
+	min, err := parseFloat(lo)
+	if err != nil {
+		return nil, err
+	}
+	max, err := parseFloat(hi)
+	if err != nil {
+		return nil, err
+	}
+	if max < min {
+		return nil, fmt.Errorf("max %v is less than min %v", max, min)
+	}
+	return rules.Range{Min: min, Max: max}, nil
+}
+
+// oneOfFactory accepts a space separated list of allowed values.
+func oneOfFactory(param string) (rules.Rule, error) {
+	fields := strings.Fields(param)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("expected at least one value")
+	}
+	values := make([]interface{}, len(fields))
+	for i, f := range fields {
+		values[i] = f
+	}
+	return rules.OneOf{Values: values}, nil
+}
+
+func regexFactory(param string) (rules.Rule, error) {
+	if param == "" {
+		return nil, fmt.Errorf("expected a pattern")
+	}
+	re, err := regexp.Compile(param)
+	if err != nil {
+		return nil, err
+	}
+	return rules.Regex{Pattern: re}, nil
+}
+
+// portFactory accepts no parameter for any unprivileged port, or "min:max".
+func portFactory(param string) (rules.Rule, error) {
+	if param == "" {
+		return rules.Port{}, nil
+	}
+	lo, hi, ok := strings.Cut(param, ":")
+	if !ok {
+		return nil, fmt.Errorf("expected min:max")
+	}
+	min, err := parseInt(lo)
+	if err != nil {
+		return nil, err
+	}
+	max, err := parseInt(hi)
+	if err != nil {
+		return nil, err
+	}
+	if min < 0 || max > 65535 || max < min {
+		return nil, fmt.Errorf("invalid port range %d:%d", min, max)
+	}
+	return rules.Port{Min: min, Max: max, AllowPrivileged: min < 1024}, nil
+}
+
+// dateFactory accepts a time layout and defaults to 2006-01-02.
+func dateFactory(param string) (rules.Rule, error) {
+	if param == "" {
+		return rules.Date{Format: "2006-01-02"}, nil
+	}
+	return rules.Date{Format: param}, nil
+}
+
+// timeFactory accepts a time layout and defaults to RFC 3339.
+func timeFactory(param string) (rules.Rule, error) {
+	if param == "" {
+		return rules.TimeFormat{Layout: time.RFC3339}, nil
+	}
+	return rules.TimeFormat{Layout: param}, nil
+}
+
+// urlFactory accepts an optional space separated list of allowed schemes.
+func urlFactory(param string) (rules.Rule, error) {
+	return rules.URL{AllowedSchemes: strings.Fields(param)}, nil
+}
+
+// passwordFactory accepts an optional minimum length, 8 by default. Upper
+// case, lower case and digit characters are always required.
+func passwordFactory(param string) (rules.Rule, error) {
+	min := 8
+	if param != "" {
+		var err error
+		if min, err = parseInt(param); err != nil {
+			return nil, err
+		}
+	}
+	return rules.Password{
+		MinLength:    min,
+		RequireUpper: true,
+		RequireLower: true,
+		RequireDigit: true,
+	}, nil
+}
+
+func containsFactory(param string) (rules.Rule, error) {
+	if param == "" {
+		return nil, fmt.Errorf("expected a value")
+	}
+	return rules.Contains{Value: param}, nil
+}
+
+// exprFactory parses the expression of an expr tag. It is type-checked
+// against the struct holding the field when the struct type is compiled.
+func exprFactory(param string) (rules.Rule, error) {
+	program, err := expr.Compile(param)
+	if err != nil {
+		return nil, err
+	}
+	return rules.Expr{Program: program}, nil
+}
+
+func parseFloat(param string) (float64, error) {
+	val, err := strconv.ParseFloat(strings.TrimSpace(param), 64)
+	if err != nil {
+		return 0, fmt.Errorf("expected a number")
+	}
+	return val, nil
+}
+
+func parseInt(param string) (int, error) {
+	val, err := strconv.Atoi(strings.TrimSpace(param))
+	if err != nil {
+		return 0, fmt.Errorf("expected an integer")
+	}
+	return val, nil
+}
+
+// fieldFactory builds the comparison with the field named by param, e.g.
+// "gtfield=CreatedAt". The csfield variants resolve param from the validated
+// value instead of the struct holding the field. Without a parameter, the
+// field variants compare with the other value of VarWithField.
+func fieldFactory(op string, crossStruct bool) RuleFactory {
+	return func(param string) (rules.Rule, error) {
+		switch {
+		case param == "" && crossStruct:
+			return nil, fmt.Errorf("expected a field path")
+		case param != "" && slices.Contains(strings.Split(param, "."), ""):
+			return nil, fmt.Errorf("invalid field path")
+		}
+		return rules.FieldCompare{Field: param, Op: op, CrossStruct: crossStruct}, nil
+	}
+}
+
+// presenceFactory builds a conditional presence rule. The if and unless
+// conditions take field/value pairs, e.g. "required_if=ContactMethod phone",
+// and the others a list of fields, e.g. "required_without_all=Email Phone".
+func presenceFactory(exclude bool, when string) RuleFactory {
+	return func(param string) (rules.Rule, error) {
+		args := strings.Fields(param)
+		p := rules.Presence{Exclude: exclude, When: when}
+		switch {
+		case when != rules.WhenIf && when != rules.WhenUnless:
+			if len(args) == 0 {
+				return nil, fmt.Errorf("expected at least one field")
+			}
+			p.Fields = args
+		case len(args) == 0 || len(args)%2 != 0:
+			return nil, fmt.Errorf("expected field and value pairs")
+		default:
+			for i := 0; i < len(args); i += 2 {
+				p.Fields = append(p.Fields, args[i])
+				p.Values = append(p.Values, args[i+1])
+			}
+		}
+		return p, nil
+	}
+}
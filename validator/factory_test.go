@@ -0,0 +1,189 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+func TestRuleFactories(t *testing.T) {
+	v := New()
+
+	tests := []struct {
+		name    string
+		tag     string
+		value   interface{}
+		wantErr bool
+	}{
+		{"bounded length", "length=3:20", "widget", false},
+		{"name too short", "length=3:20", "ab", true},
+		{"code wrong length", "length=5", "AB12", true},
+		{"quantity in bounds", "min=1,max=10", 5, false},
+		{"quantity below min", "min=1,max=10", 0, true},
+		{"quantity above max", "min=1,max=10", 11, true},
+		{"score out of range", "range=0:100", 101.0, true},
+		{"status allowed", "oneof=draft published", "draft", false},
+		{"status not allowed", "oneof=draft published", "archived", true},
+		{"priority not allowed", "oneof=1 2 3", 4, true},
+		{"sku", "regex=^[A-Z]{3}[0-9]+$", "ABC123", false},
+		{"sku mismatch", "regex=^[A-Z]{3}[0-9]+$", "abc", true},
+		{"port out of range", "port=1:65535", 70000, true},
+		{"date", "date", "2024-05-01", false},
+		{"bad date", "date", "01/05/2024", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Var(tt.value, tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Var(%v, %q) error = %v, wantErr %v", tt.value, tt.tag, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleFactories_StringLength(t *testing.T) {
+	v := New()
+
+	tests := []struct {
+		name    string
+		value   string
+		tag     string
+		wantErr bool
+	}{
+		{"len exact", "12345", "len=5", false},
+		{"len short", "1234", "len=5", true},
+		{"len bounded", "abc", "len=3:20", false},
+		{"len zero", "", "len=0", false},
+		{"len zero non-empty", "a", "len=0", true},
+		{"length counts characters", "äöü", "length=3:5", false},
+		{"len counts characters", "äöü", "len=3", false},
+		{"max string", "abc", "max=3", false},
+		{"max long string", "abcd", "max=3", true},
+		{"min string", "go", "min=3", true},
+		{"min counts characters", "äöü", "min=3", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Var(tt.value, tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Var(%q, %q) error = %v, wantErr %v", tt.value, tt.tag, err, tt.wantErr)
+			}
+		})
+	}
+
+	var fe *FieldError
+	if err := v.Var("abcd", "max=3"); !errors.As(err, &fe) || fe.Message != "must be at most 3 characters" {
+		t.Errorf("Var() error = %v, want the string length message", err)
+	}
+}
+
+func TestRuleFactories_LegacyColonSyntax(t *testing.T) {
+	v := New()
+
+	type User struct {
+		Username string `validate:"length:3:20"`
+	}
+
+	if err := v.Validate(User{Username: "johndoe"}); err != nil {
+		t.Errorf("Validate() unexpected error = %v", err)
+	}
+	if err := v.Validate(User{Username: "jo"}); err == nil {
+		t.Error("Validate() expected error for short username")
+	}
+}
+
+func TestRuleFactories_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"bad max", struct {
+			N int `validate:"max=ten"`
+		}{}},
+		{"bad length", struct {
+			S string `validate:"length=3:x"`
+		}{}},
+		{"negative len", struct {
+			S string `validate:"len=-1"`
+		}{}},
+		{"inverted range", struct {
+			N int `validate:"range=10:1"`
+		}{}},
+		{"empty oneof", struct {
+			S string `validate:"oneof="`
+		}{}},
+		{"bad regex", struct {
+			S string `validate:"regex=[a-"`
+		}{}},
+		{"bad port", struct {
+			N int `validate:"port=1"`
+		}{}},
+		{"missing parameter", struct {
+			N int `validate:"max"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := New().Validate(tt.value); err == nil {
+				t.Error("Validate() expected a parse error")
+			}
+		})
+	}
+}
+
+func TestRuleFactories_ErrorOnFirstUse(t *testing.T) {
+	v := New()
+
+	type Item struct {
+		Tags []string
+		Max  int `validate:"max=oops"`
+	}
+
+	// The malformed tag is reported even though the field is never reached
+	// with a value that would fail.
+	err := v.Validate(Item{})
+	if err == nil {
+		t.Fatal("Validate() expected a parse error")
+	}
+	want := `Max: invalid max parameter "oops": expected a number`
+	if err.Error() != want {
+		t.Errorf("Validate() error = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestAddRuleFactory(t *testing.T) {
+	v := New()
+	calls := 0
+	v.AddRuleFactory("divisible", func(param string) (rules.Rule, error) {
+		calls++
+		n, err := parseInt(param)
+		if err != nil {
+			return nil, err
+		}
+		return rules.Custom{Fn: func(value interface{}) error {
+			if value.(int)%n != 0 {
+				return fmt.Errorf("value must be divisible by %d", n)
+			}
+			return nil
+		}}, nil
+	})
+
+	type Batch struct {
+		Size int `validate:"divisible=4"`
+	}
+
+	if err := v.Validate(Batch{Size: 8}); err != nil {
+		t.Errorf("Validate() unexpected error = %v", err)
+	}
+	if err := v.Validate(Batch{Size: 6}); err == nil {
+		t.Error("Validate() expected error for size 6")
+	}
+	if calls != 1 {
+		t.Errorf("factory called %d times, want 1", calls)
+	}
+}
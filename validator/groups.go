@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type groupsKey struct{}
+
+// WithGroups returns a context that activates validation groups, such as
+// "create" or "admin", for a ValidateCtx or ValidateAllCtx call. Rules
+// without a group always apply; grouped rules only apply while one of their
+// groups is active, in nested structs too.
+//
+// A field is assigned rules for a group with a validate.<group> tag, or by
+// restricting its validate tag with groups=:
+//
+//	Password string `validate:"omitempty,password" validate.create:"required"`
+//	Role     string `validate:"required,oneof=admin editor,groups=admin|owner"`
+func WithGroups(ctx context.Context, groups ...string) context.Context {
+	set := make([]string, 0, len(groups))
+	for _, g := range groups {
+		if g != "" {
+			set = append(set, g)
+		}
+	}
+	slices.Sort(set)
+	set = slices.Compact(set)
+	return context.WithValue(ctx, groupsKey{}, strings.Join(set, ","))
+}
+
+// ValidateGroups is like Validate with groups active; see WithGroups.
+func (v *Validator) ValidateGroups(value interface{}, groups ...string) error {
+	return v.ValidateCtx(WithGroups(context.Background(), groups...), value)
+}
+
+// groupsOf returns the active groups of ctx, sorted and joined by commas.
+func groupsOf(ctx context.Context) string {
+	groups, _ := ctx.Value(groupsKey{}).(string)
+	return groups
+}
+
+func splitGroups(groups string) []string {
+	if groups == "" {
+		return nil
+	}
+	return strings.Split(groups, ",")
+}
+
+// fieldRules resolves the rules of field for the active groups: the
+// validate.<group> tags of the groups, then the validate tag. Group rules
+// come first so that a group tag starting with omitempty makes the field
+// optional in that group. A dive, which takes the rest of its tag, is moved
+// after the rules of every tag, and only one of the tags may have one.
+func (v *Validator) fieldRules(field reflect.StructField, groups []string) ([]tagRule, error) {
+	var resolved []tagRule
+	var dive *tagRule
+	add := func(rules []tagRule) error {
+		if n := len(rules); n > 0 && rules[n-1].name == "dive" {
+			if dive != nil {
+				return fmt.Errorf("dive is already used by another validate tag of the field")
+			}
+			dive = &rules[n-1]
+			rules = rules[:n-1]
+		}
+		resolved = append(resolved, rules...)
+		return nil
+	}
+
+	for _, g := range groups {
+		tag, ok := field.Tag.Lookup("validate." + g)
+		if !ok {
+			continue
+		}
+		rules, err := v.parseGroupTag(tag, groups)
+		if err == nil {
+			err = add(rules)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("validate.%s: %w", g, err)
+		}
+	}
+
+	rules, err := v.parseGroupTag(field.Tag.Get("validate"), groups)
+	if err == nil {
+		err = add(rules)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if dive != nil {
+		resolved = append(resolved, *dive)
+	}
+	return resolved, nil
+}
+
+// parseGroupTag parses tag, which applies only while one of the groups of
+// its groups= entry, if any, is active.
+func (v *Validator) parseGroupTag(tag string, active []string) ([]tagRule, error) {
+	if tag == "" {
+		return nil, nil
+	}
+	entries, err := lexTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	restricted, matched := false, false
+	kept := make([][]tagTerm, 0, len(entries))
+	for _, alts := range entries {
+		if alts[0].name != "groups" {
+			kept = append(kept, alts)
+			continue
+		}
+		if !alts[0].hasParam || alts[0].param == "" {
+			return nil, fmt.Errorf("groups requires at least one group")
+		}
+		restricted = true
+		for i, term := range alts {
+			group := term.raw
+			if i == 0 {
+				group = term.param
+			}
+			if slices.Contains(active, group) {
+				matched = true
+			}
+		}
+	}
+
+	resolved, err := v.resolveEntries(kept)
+	if err != nil || (restricted && !matched) {
+		return nil, err
+	}
+	return resolved, nil
+}
@@ -0,0 +1,102 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type GroupProfile struct {
+	Bio string `validate:"length=0:10" validate.admin:"required"`
+}
+
+type GroupUser struct {
+	ID       string `validate:"required,groups=update|admin"`
+	Email    string `validate:"required,email"`
+	Password string `validate:"omitempty,length=8:64" validate.create:"required"`
+	Role     string `validate:"required,oneof=admin editor,groups=admin"`
+	Profile  *GroupProfile
+}
+
+func TestValidateGroups(t *testing.T) {
+	tests := []struct {
+		name   string
+		groups []string
+		value  GroupUser
+		want   []string
+	}{
+		{"no group", nil, GroupUser{Profile: &GroupProfile{}}, []string{"Email"}},
+		{"create", []string{"create"}, GroupUser{Email: "a@b.co"}, []string{"Password"}},
+		{"create with password", []string{"create"}, GroupUser{Email: "a@b.co", Password: "secret"}, []string{"Password"}},
+		{"update", []string{"update"}, GroupUser{Email: "a@b.co"}, []string{"ID"}},
+		{"update without password", []string{"update"}, GroupUser{ID: "1", Email: "a@b.co"}, nil},
+		{"admin", []string{"admin"}, GroupUser{Email: "a@b.co", Profile: &GroupProfile{}}, []string{"ID", "Role", "Profile.Bio"}},
+		{"several groups", []string{"update", "create", "update"}, GroupUser{Email: "a@b.co"}, []string{"ID", "Password"}},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithGroups(context.Background(), tt.groups...)
+			got := namespaces(v.ValidateAllCtx(ctx, tt.value))
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ValidateAllCtx() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := v.ValidateGroups(GroupUser{Email: "a@b.co"}, "create"); err == nil {
+		t.Error("ValidateGroups() expected an error for a missing password")
+	}
+}
+
+func TestValidateGroups_DiveRunsLast(t *testing.T) {
+	type GroupDive struct {
+		Tags []string `validate:"required" validate.create:"dive,email"`
+	}
+
+	v := New()
+	var fe *FieldError
+	err := v.ValidateGroups(GroupDive{}, "create")
+	if !errors.As(err, &fe) || fe.Rule != "required" {
+		t.Errorf("ValidateGroups() error = %v, want the ungrouped required rule", err)
+	}
+	err = v.ValidateGroups(GroupDive{Tags: []string{"a@b.co", "nope"}}, "create")
+	if !errors.As(err, &fe) || fe.Namespace != "Tags[1]" || fe.Rule != "email" {
+		t.Errorf("ValidateGroups() error = %v, want an email error on Tags[1]", err)
+	}
+}
+
+func TestValidateGroups_TagErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"empty groups", struct {
+			Name string `validate:"required,groups="`
+		}{}},
+		{"groups as alternative", struct {
+			Name string `validate:"email|groups=create"`
+		}{}},
+		{"group tag", struct {
+			Name string `validate:"required,groups=admin" validate.create:"unknown"`
+		}{}},
+		{"inactive restricted tag", struct {
+			Name string `validate:"unknown,groups=admin"`
+		}{}},
+		{"dive in two tags", struct {
+			Tags []string `validate:"dive,required" validate.create:"dive,email"`
+		}{}},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateGroups(tt.value, "create")
+			if _, ok := err.(ValidationErrors); err == nil || ok {
+				t.Errorf("ValidateGroups() error = %v, want a tag error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package i18n
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Catalog holds the messages of a single locale.
+type Catalog struct {
+	locale   string
+	plural   PluralRule
+	messages map[string]message
+}
+
+// NewCatalog compiles messages, a map from message key to template, for
+// locale. The plural rule is chosen from the language of locale.
+func NewCatalog(locale string, messages map[string]string) (*Catalog, error) {
+	c := &Catalog{
+		locale:   locale,
+		plural:   PluralRuleFor(locale),
+		messages: make(map[string]message, len(messages)),
+	}
+	for key, tmpl := range messages {
+		m, err := parseMessage(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("i18n: %s: message %q: %v", locale, key, err)
+		}
+		c.messages[key] = m
+	}
+	return c, nil
+}
+
+// ParseJSON compiles a catalog from a JSON object mapping message keys to
+// templates, as in the embedded en.json.
+func ParseJSON(locale string, data []byte) (*Catalog, error) {
+	var messages map[string]string
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("i18n: %s: %v", locale, err)
+	}
+	return NewCatalog(locale, messages)
+}
+
+// Locale returns the locale of the catalog, e.g. "de" or "pt-BR".
+func (c *Catalog) Locale() string {
+	return c.locale
+}
+
+// SetPluralRule replaces the plural rule chosen from the locale.
+func (c *Catalog) SetPluralRule(rule PluralRule) {
+	c.plural = rule
+}
+
+// Set adds or replaces the template of key.
+func (c *Catalog) Set(key, tmpl string) error {
+	m, err := parseMessage(tmpl)
+	if err != nil {
+		return fmt.Errorf("i18n: %s: message %q: %v", c.locale, key, err)
+	}
+	c.messages[key] = m
+	return nil
+}
+
+// Format renders the message for key with params. It reports false when the
+// catalog has no message for key.
+func (c *Catalog) Format(key string, params Params) (string, bool) {
+	m, ok := c.messages[key]
+	if !ok {
+		return "", false
+	}
+	var b strings.Builder
+	m.render(&b, params, c.plural, nil)
+	return b.String(), true
+}
+
+//go:embed en.json
+var englishJSON []byte
+
+var english = mustParseJSON("en", englishJSON)
+
+// English returns the embedded English catalog. It holds a message for every
+// key used by the rules package.
+func English() *Catalog {
+	return english
+}
+
+func mustParseJSON(locale string, data []byte) *Catalog {
+	c, err := ParseJSON(locale, data)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Bundle is a Translator over catalogs of several locales. A locale such as
+// "de-AT" falls back to "de", and then to the fallback locale, English unless
+// changed with SetFallback. Catalogs must be added before the bundle is used
+// concurrently.
+type Bundle struct {
+	catalogs map[string]*Catalog
+	fallback string
+}
+
+// NewBundle returns a bundle holding the English catalog and catalogs.
+func NewBundle(catalogs ...*Catalog) *Bundle {
+	b := &Bundle{catalogs: make(map[string]*Catalog), fallback: "en"}
+	b.Add(English())
+	for _, c := range catalogs {
+		b.Add(c)
+	}
+	return b
+}
+
+// Add registers c under its locale, replacing any catalog of the same locale.
+func (b *Bundle) Add(c *Catalog) {
+	b.catalogs[normalizeLocale(c.locale)] = c
+}
+
+// Catalog returns the catalog registered for locale, or nil.
+func (b *Bundle) Catalog(locale string) *Catalog {
+	return b.catalogs[normalizeLocale(locale)]
+}
+
+// SetFallback selects the locale used when neither the requested locale nor
+// its language has a message.
+func (b *Bundle) SetFallback(locale string) {
+	b.fallback = locale
+}
+
+func (b *Bundle) Translate(locale, key string, params Params) (string, bool) {
+	for _, loc := range []string{locale, language(locale), b.fallback} {
+		if c := b.catalogs[normalizeLocale(loc)]; c != nil {
+			if msg, ok := c.Format(key, params); ok {
+				return msg, true
+			}
+		}
+	}
+	return "", false
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// language returns the language subtag of locale, e.g. "de" for "de-AT".
+func language(locale string) string {
+	lang, _, _ := strings.Cut(normalizeLocale(locale), "-")
+	return lang
+}
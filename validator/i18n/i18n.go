@@ -0,0 +1,22 @@
+// Package i18n provides message catalogs for validation errors. Messages are
+// looked up by key, such as "password.min_length", and rendered from
+// templates with named parameters and plural forms. An English catalog is
+// embedded; catalogs for other locales are loaded with ParseJSON.
+//
+// Templates reference parameters as {name}. Plural forms use the ICU
+// syntax, where # stands for the number:
+//
+//	password must be at least {min, plural, one {# character} other {# characters}}
+//
+// Cases are selected with the plural rule of the catalog's locale. Exact
+// matches such as =0 take precedence, and "other" is required.
+package i18n
+
+// Params holds the named parameters of a message, e.g. {"min": 8}.
+type Params map[string]interface{}
+
+// Translator renders the message for key in locale. It reports false when it
+// has no message for key in that locale.
+type Translator interface {
+	Translate(locale, key string, params Params) (string, bool)
+}
@@ -0,0 +1,144 @@
+package i18n
+
+import "testing"
+
+func TestCatalog_Format(t *testing.T) {
+	c, err := NewCatalog("en", map[string]string{
+		"plain":  "value is required",
+		"param":  "length must be at least {min}",
+		"plural": "at least {n, plural, =0 {nothing} one {# item} other {# items}} in {field}",
+		"nested": "{n, plural, one {one {kind}} other {# {kind}s}}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		params Params
+		want   string
+	}{
+		{"plain", "plain", nil, "value is required"},
+		{"param", "param", Params{"min": 3}, "length must be at least 3"},
+		{"missing param", "param", nil, "length must be at least {min}"},
+		{"exact match", "plural", Params{"n": 0, "field": "Tags"}, "at least nothing in Tags"},
+		{"one", "plural", Params{"n": 1, "field": "Tags"}, "at least 1 item in Tags"},
+		{"other", "plural", Params{"n": 5, "field": "Tags"}, "at least 5 items in Tags"},
+		{"float", "plural", Params{"n": 1.5, "field": "Tags"}, "at least 1.5 items in Tags"},
+		{"not a number", "plural", Params{"n": "x", "field": "Tags"}, "at least x items in Tags"},
+		{"nested param", "nested", Params{"n": 2, "kind": "tag"}, "2 tags"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := c.Format(tt.key, tt.params)
+			if !ok || got != tt.want {
+				t.Errorf("Format() = %q, %v, want %q", got, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := c.Format("unknown", nil); ok {
+		t.Error("Format() found an unknown key")
+	}
+}
+
+func TestNewCatalog_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+	}{
+		{"unterminated", "at least {min"},
+		{"unmatched brace", "at least min}"},
+		{"empty name", "at least {}"},
+		{"unknown kind", "{n, select, a {x} other {y}}"},
+		{"missing other", "{n, plural, one {x}}"},
+		{"missing case body", "{n, plural, one x other {y}}"},
+		{"unterminated case", "{n, plural, one {x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCatalog("en", map[string]string{"key": tt.tmpl}); err == nil {
+				t.Errorf("NewCatalog(%q) expected an error", tt.tmpl)
+			}
+		})
+	}
+}
+
+func TestPluralRuleFor(t *testing.T) {
+	tests := []struct {
+		locale string
+		n      float64
+		want   string
+	}{
+		{"en", 1, "one"},
+		{"en", 0, "other"},
+		{"de-AT", 1, "one"},
+		{"es", 2, "other"},
+		{"fa", 0, "one"},
+		{"fa_IR", 1, "one"},
+		{"fa", 2, "other"},
+		{"fr", 1.5, "one"},
+		{"ja", 1, "other"},
+		{"ru", 21, "one"},
+		{"ru", 3, "few"},
+		{"ru", 11, "many"},
+		{"pl", 22, "few"},
+		{"pl", 5, "many"},
+	}
+
+	for _, tt := range tests {
+		if got := PluralRuleFor(tt.locale)(tt.n); got != tt.want {
+			t.Errorf("PluralRuleFor(%q)(%v) = %q, want %q", tt.locale, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestBundle_Translate(t *testing.T) {
+	de, err := ParseJSON("de", []byte(`{
+		"required": "Wert ist erforderlich",
+		"length.min": "Länge muss mindestens {min} betragen"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	deAT, err := NewCatalog("de-AT", map[string]string{"required": "Wert ist verpflichtend"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := NewBundle(de, deAT)
+
+	tests := []struct {
+		name   string
+		locale string
+		key    string
+		want   string
+	}{
+		{"exact locale", "de-AT", "required", "Wert ist verpflichtend"},
+		{"language fallback", "de-AT", "length.min", "Länge muss mindestens 3 betragen"},
+		{"region without catalog", "de_CH", "required", "Wert ist erforderlich"},
+		{"english fallback", "de", "ip.invalid", "invalid IP address format"},
+		{"unknown locale", "fa", "required", "value is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := b.Translate(tt.locale, tt.key, Params{"min": 3})
+			if !ok || got != tt.want {
+				t.Errorf("Translate() = %q, %v, want %q", got, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := b.Translate("de", "custom.unknown", nil); ok {
+		t.Error("Translate() found an unknown key")
+	}
+}
+
+func TestParseJSON_Invalid(t *testing.T) {
+	if _, err := ParseJSON("de", []byte(`{"required": 1}`)); err == nil {
+		t.Error("ParseJSON() expected an error for a non-string message")
+	}
+}
@@ -0,0 +1,222 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// message is a compiled template: a sequence of literal text, parameter
+// references and plural selections.
+type message []node
+
+type node struct {
+	text   string
+	param  string
+	hash   bool
+	plural map[string]message
+}
+
+func parseMessage(tmpl string) (message, error) {
+	p := parser{src: tmpl}
+	m, err := p.message(false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected '}'")
+	}
+	return m, nil
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("column %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+// message parses text up to the end of the template or an unmatched '}'.
+// Inside a plural case, # refers to the number.
+func (p *parser) message(inPlural bool) (message, error) {
+	var m message
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			m = append(m, node{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '}':
+			flush()
+			return m, nil
+		case c == '#' && inPlural:
+			flush()
+			m = append(m, node{hash: true})
+			p.pos++
+		case c == '{':
+			flush()
+			n, err := p.placeholder()
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, n)
+		default:
+			text.WriteByte(c)
+			p.pos++
+		}
+	}
+	flush()
+	return m, nil
+}
+
+// placeholder parses {name} or {name, plural, case {message} ...}.
+func (p *parser) placeholder() (node, error) {
+	start := p.pos
+	p.pos++
+	end := strings.IndexAny(p.src[p.pos:], ",}")
+	if end < 0 {
+		p.pos = start
+		return node{}, p.errorf("unterminated '{'")
+	}
+	name := strings.TrimSpace(p.src[p.pos : p.pos+end])
+	if name == "" {
+		return node{}, p.errorf("missing parameter name")
+	}
+	p.pos += end
+	if p.src[p.pos] == '}' {
+		p.pos++
+		return node{param: name}, nil
+	}
+
+	p.pos++
+	kind, rest, ok := strings.Cut(p.src[p.pos:], ",")
+	if !ok || strings.TrimSpace(kind) != "plural" {
+		return node{}, p.errorf("expected plural after %s", name)
+	}
+	p.pos = len(p.src) - len(rest)
+
+	n := node{param: name, plural: make(map[string]message)}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			p.pos = start
+			return node{}, p.errorf("unterminated '{'")
+		}
+		if p.src[p.pos] == '}' {
+			p.pos++
+			break
+		}
+
+		sel := p.pos
+		for p.pos < len(p.src) && p.src[p.pos] != '{' && p.src[p.pos] != ' ' {
+			p.pos++
+		}
+		selector := p.src[sel:p.pos]
+		p.skipSpace()
+		if p.pos >= len(p.src) || p.src[p.pos] != '{' {
+			return node{}, p.errorf("expected '{' after %s", selector)
+		}
+		p.pos++
+		m, err := p.message(true)
+		if err != nil {
+			return node{}, err
+		}
+		if p.pos >= len(p.src) {
+			return node{}, p.errorf("unterminated plural case %s", selector)
+		}
+		p.pos++
+		n.plural[selector] = m
+	}
+
+	if _, ok := n.plural["other"]; !ok {
+		return node{}, fmt.Errorf("plural %s has no other case", name)
+	}
+	return n, nil
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// render writes m with params to b. num is the value # stands for.
+func (m message) render(b *strings.Builder, params Params, rule PluralRule, num interface{}) {
+	for _, n := range m {
+		switch {
+		case n.hash:
+			fmt.Fprint(b, num)
+		case n.plural != nil:
+			value := params[n.param]
+			n.selectCase(value, rule).render(b, params, rule, value)
+		case n.param != "":
+			if value, ok := params[n.param]; ok {
+				fmt.Fprint(b, value)
+			} else {
+				b.WriteString("{" + n.param + "}")
+			}
+		default:
+			b.WriteString(n.text)
+		}
+	}
+}
+
+func (n node) selectCase(value interface{}, rule PluralRule) message {
+	f, ok := toFloat(value)
+	if !ok {
+		return n.plural["other"]
+	}
+	if m, ok := n.plural[fmt.Sprintf("=%v", f)]; ok {
+		return m
+	}
+	if m, ok := n.plural[rule(f)]; ok {
+		return m
+	}
+	return n.plural["other"]
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		return f, !math.IsNaN(f)
+	}
+	return 0, false
+}
+
+// Template is a compiled message template outside of a catalog, such as a
+// per-field message override.
+type Template struct {
+	m message
+}
+
+// ParseTemplate compiles tmpl, which uses the same syntax as catalog
+// messages.
+func ParseTemplate(tmpl string) (*Template, error) {
+	m, err := parseMessage(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{m: m}, nil
+}
+
+// Render renders t with params, selecting plural cases with the rule of
+// locale.
+func (t *Template) Render(locale string, params Params) string {
+	var b strings.Builder
+	t.m.render(&b, params, PluralRuleFor(locale), nil)
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package i18n
+
+import "math"
+
+// PluralRule returns the CLDR plural category of n, such as "one" or "other".
+type PluralRule func(n float64) string
+
+// PluralRuleFor returns the plural rule of the language of locale. Languages
+// without a specific rule use the English one.
+func PluralRuleFor(locale string) PluralRule {
+	switch language(locale) {
+	case "fa", "hi":
+		return pluralZeroOne
+	case "fr", "pt":
+		return pluralBelowTwo
+	case "ja", "ko", "zh", "th", "vi", "id":
+		return pluralOther
+	case "ru", "uk":
+		return pluralEastSlavic
+	case "pl":
+		return pluralPolish
+	}
+	return pluralOne
+}
+
+// pluralOne is the rule of English, German, Spanish and most European
+// languages: one for exactly 1.
+func pluralOne(n float64) string {
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// pluralZeroOne is the rule of Persian and Hindi: one from 0 up to 1.
+func pluralZeroOne(n float64) string {
+	if math.Abs(n) <= 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// pluralBelowTwo is the rule of French and Portuguese: one below 2.
+func pluralBelowTwo(n float64) string {
+	if math.Abs(n) < 2 {
+		return "one"
+	}
+	return "other"
+}
+
+func pluralOther(float64) string {
+	return "other"
+}
+
+func pluralEastSlavic(n float64) string {
+	if n != math.Trunc(n) {
+		return "other"
+	}
+	i := int64(math.Abs(n))
+	switch {
+	case i%10 == 1 && i%100 != 11:
+		return "one"
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return "few"
+	}
+	return "many"
+}
+
+func pluralPolish(n float64) string {
+	if n != math.Trunc(n) {
+		return "other"
+	}
+	i := int64(math.Abs(n))
+	switch {
+	case i == 1:
+		return "one"
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return "few"
+	}
+	return "many"
+}
@@ -0,0 +1,85 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/sgh370/goov/validator/i18n"
+	"github.com/sgh370/goov/validator/rules"
+)
+
+// SetMessage overrides the message of a rule for every field, e.g.
+//
+//	v.SetMessage("required", "{label} is required")
+//	v.SetMessage("length.min", "{label} needs at least {min} characters")
+//
+// rule is either a rule name or a message key, which takes precedence over
+// the rule name. Templates use the syntax of the i18n package and can
+// reference the parameters of the message, such as {other}, the field an
+// eqfield rule compares with, as well as {label}, {field}, {param}, {value},
+// {rule}, {alias} and {message}, the default message. An empty template
+// removes the override. rule may also name an alias of RegisterAlias, whose
+// override applies to its rules without one of their own.
+//
+// The msg struct tag and the message= tag modifier take precedence over
+// SetMessage.
+func (v *Validator) SetMessage(rule, template string) error {
+	if template == "" {
+		delete(v.messages, rule)
+		return nil
+	}
+	t, err := i18n.ParseTemplate(template)
+	if err != nil {
+		return fmt.Errorf("invalid message for %s: %w", rule, err)
+	}
+	v.messages[rule] = t
+	return nil
+}
+
+// customize replaces the message of fe by the first of templates that is
+// set, or else by a SetMessage override.
+func (w *walker) customize(fe *FieldError, templates ...*i18n.Template) {
+	var t *i18n.Template
+	for _, tmpl := range templates {
+		if tmpl != nil {
+			t = tmpl
+			break
+		}
+	}
+	if t == nil && fe.Key != "" {
+		t = w.v.messages[fe.Key]
+	}
+	if t == nil {
+		t = w.v.messages[fe.Rule]
+	}
+	if t == nil && fe.Alias != "" {
+		t = w.v.messages[fe.Alias]
+	}
+	if t == nil {
+		return
+	}
+	fe.Message = t.Render(w.locale, w.messageParams(fe))
+}
+
+// messageParams returns the parameters a message template of fe can use.
+// Parameters of the rule take precedence over the built-in ones of the same
+// name.
+func (w *walker) messageParams(fe *FieldError) i18n.Params {
+	params := make(i18n.Params, len(fe.Params)+7)
+	params["label"] = fe.Label
+	params["field"] = fe.Field
+	params["param"] = fe.Param
+	params["value"] = fe.Value
+	params["rule"] = fe.Rule
+	params["alias"] = fe.Alias
+	params["message"] = fe.Message
+	for name, value := range fe.Params {
+		if nested, ok := value.(*rules.Error); ok {
+			value = nested.Message
+			if msg, ok := w.v.translate(w.locale, nested.Key, nested.Params); ok {
+				value = msg
+			}
+		}
+		params[name] = value
+	}
+	return params
+}
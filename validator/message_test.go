@@ -0,0 +1,131 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type MessageSignup struct {
+	Username string `label:"Username" validate:"required,length=3:20,message='Pick a {label} between {min} and {max} characters'"`
+	Email    string `msg:"{label} must be a valid email, got {value}" label:"E-mail" validate:"required,email"`
+	Age      int    `validate:"range=18:130"`
+	Website  string `validate:"omitempty,url"`
+}
+
+func TestValidate_MessageOverrides(t *testing.T) {
+	v := New()
+	if err := v.SetMessage("range", "{label} must be between {min} and {max}"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetMessage("url.invalid", "{label}: {message}"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		value   MessageSignup
+		wantKey string
+		wantMsg string
+	}{
+		{"message modifier", MessageSignup{Username: "jo"}, "length.min", "Pick a Username between 3 and 20 characters"},
+		{"message applies to its rule only", MessageSignup{}, "required", "value is required"},
+		{"msg tag", MessageSignup{Username: "john", Email: "nope"}, "email.invalid", "E-mail must be a valid email, got nope"},
+		{"msg tag covers every rule", MessageSignup{Username: "john"}, "required", "E-mail must be a valid email, got "},
+		{"rule override", MessageSignup{Username: "john", Email: "john@example.com", Age: 12}, "range.min", "Age must be between 18 and 130"},
+		{"key override", MessageSignup{Username: "john", Email: "john@example.com", Age: 30, Website: "::"}, "url.invalid", "Website: invalid URL format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fe := firstMessage(t, v.Validate(tt.value))
+			if fe.Key != tt.wantKey || fe.Message != tt.wantMsg {
+				t.Errorf("Key = %q, Message = %q, want %q, %q", fe.Key, fe.Message, tt.wantKey, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestValidate_MessageOverridesLocale(t *testing.T) {
+	v := New()
+	v.SetTranslator(germanBundle(t))
+	if err := v.SetMessage("required", "{label}: {message}"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := WithLocale(context.Background(), "de")
+	fe := firstMessage(t, v.ValidateCtx(ctx, MessageSignup{}))
+	if fe.Message != "Username: Wert ist erforderlich" {
+		t.Errorf("Message = %q, want the translated message with its label", fe.Message)
+	}
+
+	if err := v.SetMessage("required", ""); err != nil {
+		t.Fatal(err)
+	}
+	if fe := firstMessage(t, v.ValidateCtx(ctx, MessageSignup{})); fe.Message != "Wert ist erforderlich" {
+		t.Errorf("Message = %q, want the override removed", fe.Message)
+	}
+}
+
+func TestValidate_MessageTagErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr string
+	}{
+		{"message without rule", struct {
+			Name string `validate:"message='x'"`
+		}{}, "message must follow a rule"},
+		{"message after modifier", struct {
+			Name string `validate:"omitempty,message='x'"`
+		}{}, "message must follow a rule"},
+		{"message as alternative", struct {
+			Name string `validate:"email|message='x'"`
+		}{}, "message cannot be used as an alternative"},
+		{"message without template", struct {
+			Name string `validate:"required,message"`
+		}{}, "Name: message requires a template"},
+		{"message with empty template", struct {
+			Name string `validate:"required,message="`
+		}{}, "Name: message requires a template"},
+		{"invalid template", struct {
+			Name string `validate:"required,message='{label'"`
+		}{}, "invalid message"},
+		{"invalid msg tag", struct {
+			Name string `msg:"{n, select, a {x}}" validate:"required"`
+		}{}, "invalid msg tag"},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.value)
+			if err == nil {
+				t.Fatal("Validate() expected an error")
+			}
+			if _, ok := err.(ValidationErrors); ok || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want a tag error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := v.SetMessage("required", "{label"); err == nil {
+		t.Error("SetMessage() expected an error for an invalid template")
+	}
+}
+
+func TestSetMessage_OtherField(t *testing.T) {
+	type Confirm struct {
+		Password string
+		Confirm  string `validate:"eqfield=Password"`
+	}
+
+	v := New()
+	if err := v.SetMessage("eqfield", "{field} must match {other}"); err != nil {
+		t.Fatal(err)
+	}
+	err := v.Validate(Confirm{Password: "a", Confirm: "b"})
+	if want := "Confirm: Confirm must match Password"; err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldNameFunc returns the name a struct field is reported under in error
+// paths. An empty result falls back to the Go field name.
+type FieldNameFunc func(field reflect.StructField) string
+
+// Field name functions for common struct tags. Options such as omitempty are
+// ignored, and fields tagged "-" or without the tag keep their Go name.
+var (
+	JSONFieldName = TagFieldName("json")
+	YAMLFieldName = TagFieldName("yaml")
+	FormFieldName = TagFieldName("form")
+)
+
+// TagFieldName returns a FieldNameFunc that reads the name of a field from
+// the struct tag key, e.g. TagFieldName("xml").
+func TagFieldName(key string) FieldNameFunc {
+	return func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	}
+}
+
+// SetFieldNameFunc selects the names used for struct fields in error paths,
+// e.g. v.SetFieldNameFunc(validator.JSONFieldName) reports
+// shipping_addr.zip_code instead of ShippingAddr.ZipCode. Rule parameters
+// that refer to other fields keep using Go field names.
+func (v *Validator) SetFieldNameFunc(fn FieldNameFunc) {
+	v.fieldNameFunc = fn
+	v.resetPlans()
+}
+
+// fieldName returns the reported name of a struct field.
+func (v *Validator) fieldName(field reflect.StructField) string {
+	if v.fieldNameFunc != nil {
+		if name := v.fieldNameFunc(field); name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// fieldLabel returns the human friendly name of a field from its label tag,
+// or its reported name when it has none.
+func (v *Validator) fieldLabel(field reflect.StructField) string {
+	if label := field.Tag.Get("label"); label != "" {
+		return label
+	}
+	return v.fieldName(field)
+}
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type NamesAddress struct {
+	ZipCode string `json:"zip_code" yaml:"zipCode" form:"zip" label:"Shipping ZIP" validate:"required"`
+}
+
+type NamesOrder struct {
+	ID           string            `json:"id,omitempty" validate:"required"`
+	ShippingAddr *NamesAddress     `json:"shipping_addr" yaml:"shippingAddr"`
+	Items        []string          `json:"items" validate:"dive,required"`
+	Notes        map[string]string `json:"-" validate:"dive,required"`
+}
+
+func TestSetFieldNameFunc(t *testing.T) {
+	tests := []struct {
+		name      string
+		fn        FieldNameFunc
+		value     NamesOrder
+		namespace string
+		label     string
+	}{
+		{"go names", nil, NamesOrder{ID: "1", ShippingAddr: &NamesAddress{}}, "ShippingAddr.ZipCode", "Shipping ZIP"},
+		{"json names", JSONFieldName, NamesOrder{ID: "1", ShippingAddr: &NamesAddress{}}, "shipping_addr.zip_code", "Shipping ZIP"},
+		{"yaml names", YAMLFieldName, NamesOrder{ID: "1", ShippingAddr: &NamesAddress{}}, "shippingAddr.zipCode", "Shipping ZIP"},
+		{"form names", FormFieldName, NamesOrder{ID: "1", ShippingAddr: &NamesAddress{}}, "ShippingAddr.zip", "Shipping ZIP"},
+		{"tag options", JSONFieldName, NamesOrder{}, "id", "id"},
+		{"element", JSONFieldName, NamesOrder{ID: "1", Items: []string{"a", ""}}, "items[1]", "items"},
+		{"ignored tag", JSONFieldName, NamesOrder{ID: "1", Notes: map[string]string{"k": ""}}, `Notes["k"]`, "Notes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetFieldNameFunc(tt.fn)
+
+			var verrs ValidationErrors
+			if err := v.Validate(tt.value); !errors.As(err, &verrs) {
+				t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+			}
+			if verrs[0].Namespace != tt.namespace || verrs[0].Label != tt.label {
+				t.Errorf("Namespace = %q, Label = %q, want %q, %q", verrs[0].Namespace, verrs[0].Label, tt.namespace, tt.label)
+			}
+		})
+	}
+}
+
+func TestSetFieldNameFunc_FieldViolation(t *testing.T) {
+	v := New()
+	v.SetFieldNameFunc(JSONFieldName)
+	v.RegisterStructRule(reflect.TypeOf(NamesOrder{}), func(ctx context.Context, value interface{}) error {
+		return &FieldViolation{Field: "ShippingAddr.ZipCode", Message: "is not served"}
+	})
+
+	var verrs ValidationErrors
+	order := NamesOrder{ID: "1", ShippingAddr: &NamesAddress{ZipCode: "10115"}}
+	if err := v.Validate(order); !errors.As(err, &verrs) {
+		t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+	}
+	fe := verrs[0]
+	if fe.Namespace != "shipping_addr.zip_code" || fe.Label != "Shipping ZIP" || fe.Value != "10115" {
+		t.Errorf("FieldError = %+v", fe)
+	}
+}
@@ -0,0 +1,79 @@
+package validator
+
+import "testing"
+
+type OmitContact struct {
+	Email string `validate:"required,email"`
+}
+
+type OmitProfile struct {
+	Website  string       `validate:"omitempty,url"`
+	Phone    string       `validate:"omitempty,phone"`
+	Port     *int         `validate:"omitnil,port=1024:65535"`
+	Nickname *string      `validate:"omitempty,length=3:20"`
+	Tags     []string     `validate:"omitnil,min=1"`
+	Backup   *OmitContact `validate:"omitnil"`
+	Billing  OmitContact  `validate:"omitempty"`
+	Country  string       `validate:"required,omitempty,length=2"`
+}
+
+func TestValidate_OmitModifiers(t *testing.T) {
+	port := func(n int) *int { return &n }
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		value   OmitProfile
+		wantErr bool
+	}{
+		{"all optional fields unset", OmitProfile{Country: "DE"}, false},
+		{"website set and valid", OmitProfile{Website: "https://example.com", Country: "DE"}, false},
+		{"website set and invalid", OmitProfile{Website: "not-a-url", Country: "DE"}, true},
+		{"phone set and invalid", OmitProfile{Phone: "abc", Country: "DE"}, true},
+		{"port nil", OmitProfile{Port: nil, Country: "DE"}, false},
+		{"port zero is validated", OmitProfile{Port: port(0), Country: "DE"}, true},
+		{"port set and valid", OmitProfile{Port: port(8080), Country: "DE"}, false},
+		{"nickname points to empty", OmitProfile{Nickname: str(""), Country: "DE"}, false},
+		{"nickname too short", OmitProfile{Nickname: str("ab"), Country: "DE"}, true},
+		{"empty tags are validated", OmitProfile{Tags: []string{}, Country: "DE"}, true},
+		{"backup set and invalid", OmitProfile{Backup: &OmitContact{}, Country: "DE"}, true},
+		{"billing partly set", OmitProfile{Billing: OmitContact{Email: "bad"}, Country: "DE"}, true},
+		{"modifier after required", OmitProfile{}, true},
+		{"country too long", OmitProfile{Country: "DEU"}, true},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_OmitModifierErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"parameter", struct {
+			S string `validate:"omitempty=1"`
+		}{}},
+		{"alternative", struct {
+			S string `validate:"omitempty|email"`
+		}{}},
+		{"slice parameter", struct {
+			S []string `validate:"slice=omitnil"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := New().Validate(tt.value); err == nil {
+				t.Error("Validate() expected a tag error")
+			}
+		})
+	}
+}
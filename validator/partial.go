@@ -0,0 +1,122 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ValidateFields is like Validate but only checks the given fields, e.g.
+//
+//	v.ValidateFields(signup, "Username", "BillingAddr.City", "Items.Quantity")
+//
+// Paths name fields by their Go names or the names reported in errors,
+// joined by dots. A path through a slice, array or map selects the field in
+// every element. Selecting a field validates it with everything beneath it;
+// the rules of the structs leading to it are not applied. Struct-level checks
+// only run for structs that are selected as a whole.
+//
+// Fields that are not selected are still visible to cross-field rules.
+func (v *Validator) ValidateFields(value interface{}, fields ...string) error {
+	return v.validatePartial(value, fields, false)
+}
+
+// ValidateExcept is like Validate but skips the given fields, named as for
+// ValidateFields, and everything beneath them. Struct-level checks of the
+// structs containing a skipped field do not run.
+func (v *Validator) ValidateExcept(value interface{}, fields ...string) error {
+	return v.validatePartial(value, fields, true)
+}
+
+func (v *Validator) validatePartial(value interface{}, fields []string, except bool) error {
+	verrs, err := v.validate(context.Background(), value, false, &partial{fields: fields, except: except})
+	if err != nil {
+		return err
+	}
+	if len(verrs) > 0 {
+		return verrs
+	}
+	return nil
+}
+
+// partial holds the field paths of a ValidateFields or ValidateExcept call
+// until the type of the value is known.
+type partial struct {
+	fields []string
+	except bool
+}
+
+// fieldFilter selects the fields of a struct that a partial validation
+// visits. A nil filter visits every field.
+type fieldFilter struct {
+	except bool
+	// all is set when the path ends here: the field is selected, or skipped,
+	// as a whole.
+	all bool
+	// fields holds the filters of the named fields, by Go name.
+	fields map[string]*fieldFilter
+}
+
+// newFieldFilter resolves the paths of p against the struct type typ.
+func (v *Validator) newFieldFilter(typ reflect.Type, p *partial) (*fieldFilter, error) {
+	root := &fieldFilter{except: p.except}
+	for _, path := range p.fields {
+		node, t := root, typ
+		for _, name := range strings.Split(path, ".") {
+			sf, ok := v.lookupField(t, name)
+			if !ok {
+				return nil, fmt.Errorf("unknown field %q in %s", path, typ)
+			}
+			if node.all {
+				break
+			}
+			if node.fields == nil {
+				node.fields = make(map[string]*fieldFilter)
+			}
+			child := node.fields[sf.Name]
+			if child == nil {
+				child = &fieldFilter{except: p.except}
+				node.fields[sf.Name] = child
+			}
+			node, t = child, sf.Type
+		}
+		node.all, node.fields = true, nil
+	}
+	return root, nil
+}
+
+// lookupField finds the field name, by Go or reported name, of typ or of the
+// struct elements of typ.
+func (v *Validator) lookupField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if sf.IsExported() && (sf.Name == name || v.fieldName(sf) == name) {
+			return sf, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// visit reports whether the field name is validated, and the filter of its
+// own fields, which is nil when the field is validated as a whole.
+func (f *fieldFilter) visit(name string) (*fieldFilter, bool) {
+	if f == nil {
+		return nil, true
+	}
+	child := f.fields[name]
+	switch {
+	case child == nil:
+		return nil, f.except
+	case child.all:
+		return nil, !f.except
+	}
+	return child, true
+}
@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+type PartialAddress struct {
+	Street string `validate:"required"`
+	City   string `json:"city" validate:"required"`
+}
+
+type PartialItem struct {
+	SKU      string `validate:"required"`
+	Quantity int    `validate:"min=1"`
+}
+
+type PartialSignup struct {
+	Username        string          `validate:"required,length=3:20"`
+	Email           string          `validate:"required,email"`
+	Password        string          `validate:"required,length=8:64"`
+	ConfirmPassword string          `validate:"matches_password"`
+	BillingAddr     *PartialAddress `validate:"required"`
+	Items           []PartialItem
+}
+
+func matchesPassword(parent, value interface{}) error {
+	if parent.(*PartialSignup).Password != value {
+		return errors.New("passwords do not match")
+	}
+	return nil
+}
+
+func (s PartialSignup) Validate() error {
+	return fmt.Errorf("struct-level checks must not run on partial signups")
+}
+
+func TestValidateFields(t *testing.T) {
+	s := PartialSignup{
+		Username:        "jo",
+		ConfirmPassword: "secret",
+		Items:           []PartialItem{{SKU: "a", Quantity: 0}, {Quantity: 1}},
+	}
+
+	tests := []struct {
+		name   string
+		fields []string
+		want   []string
+	}{
+		{"top level", []string{"Email", "Username"}, []string{"Username"}},
+		{"nil parent", []string{"BillingAddr.City"}, nil},
+		{"element field", []string{"Items.Quantity"}, []string{"Items[0].Quantity"}},
+		{"element fields", []string{"Items.SKU"}, []string{"Items[1].SKU"}},
+		{"whole slice", []string{"Items"}, []string{"Items[0].Quantity"}},
+		{"parent and child", []string{"Items.SKU", "Items"}, []string{"Items[0].Quantity"}},
+		{"cross-field reads excluded field", []string{"ConfirmPassword"}, []string{"ConfirmPassword"}},
+	}
+
+	v := New()
+	v.AddRule("matches_password", rules.CrossField{ValidateFn: matchesPassword})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := namespaces(fieldErrors(v.ValidateFields(s, tt.fields...)))
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ValidateFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateExcept(t *testing.T) {
+	s := PartialSignup{
+		Username:        "john",
+		Email:           "john@example.com",
+		ConfirmPassword: "x",
+		BillingAddr:     &PartialAddress{},
+		Items:           []PartialItem{{Quantity: 0}},
+	}
+
+	tests := []struct {
+		name   string
+		fields []string
+		want   []string
+	}{
+		{"top level", []string{"ConfirmPassword", "Items"}, []string{"Password"}},
+		{"nested", []string{"Password", "ConfirmPassword", "BillingAddr.Street"}, []string{"BillingAddr.city"}},
+		{"nested by reported name", []string{"Password", "ConfirmPassword", "BillingAddr.Street", "BillingAddr.city", "Items.SKU"}, []string{"Items[0].Quantity"}},
+		{"everything", []string{"Password", "ConfirmPassword", "BillingAddr", "Items"}, nil},
+	}
+
+	v := New()
+	v.SetFieldNameFunc(JSONFieldName)
+	v.AddRule("matches_password", rules.CrossField{ValidateFn: matchesPassword})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := namespaces(fieldErrors(v.ValidateExcept(s, tt.fields...)))
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ValidateExcept() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFields_UnknownField(t *testing.T) {
+	v := New()
+	for _, path := range []string{"Usrname", "BillingAddr.Zip", "Username.Length"} {
+		err := v.ValidateFields(PartialSignup{}, path)
+		if _, ok := err.(ValidationErrors); err == nil || ok {
+			t.Errorf("ValidateFields(%q) error = %v, want an unknown field error", path, err)
+		}
+	}
+}
+
+// fieldErrors returns the field errors of err in the form ValidateAll
+// reports them.
+func fieldErrors(err error) []error {
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		if err != nil {
+			return []error{err}
+		}
+		return nil
+	}
+	return verrs.Unwrap()
+}
@@ -0,0 +1,88 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PathSegment is one step of a field path: a struct field name, or the index
+// or key of a collection element when Field is empty.
+type PathSegment struct {
+	Field string
+	Key   interface{}
+}
+
+// PathFormat selects how FieldError.Namespace is rendered.
+type PathFormat int
+
+const (
+	// PathDotted renders paths like Items[2].Quantity or Contacts["home"].Value.
+	PathDotted PathFormat = iota
+	// PathJSONPointer renders paths as RFC 6901 JSON Pointers like /Items/2/Quantity.
+	PathJSONPointer
+)
+
+// SetPathFormat selects how the namespace of field errors is rendered.
+func (v *Validator) SetPathFormat(format PathFormat) {
+	v.pathFormat = format
+}
+
+func formatPath(path []PathSegment, format PathFormat) string {
+	if format == PathJSONPointer {
+		return jsonPointer(path)
+	}
+	return dottedPath(path)
+}
+
+func dottedPath(path []PathSegment) string {
+	var b strings.Builder
+	for _, seg := range path {
+		if seg.Field != "" {
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(seg.Field)
+			continue
+		}
+		if s, ok := seg.Key.(string); ok {
+			fmt.Fprintf(&b, "[%q]", s)
+		} else {
+			fmt.Fprintf(&b, "[%v]", seg.Key)
+		}
+	}
+	return b.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func jsonPointer(path []PathSegment) string {
+	var b strings.Builder
+	for _, seg := range path {
+		b.WriteByte('/')
+		if seg.Field != "" {
+			b.WriteString(pointerEscaper.Replace(seg.Field))
+		} else {
+			b.WriteString(pointerEscaper.Replace(fmt.Sprint(seg.Key)))
+		}
+	}
+	return b.String()
+}
+
+// fieldName renders the path from its last struct field onwards, e.g.
+// Items[2] for an element of the Items slice.
+func fieldName(path []PathSegment) string {
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i].Field != "" {
+			return dottedPath(path[i:])
+		}
+	}
+	return dottedPath(path)
+}
+
+// appendPath returns a copy of path extended by seg, so sibling fields never
+// share a backing array.
+func appendPath(path []PathSegment, seg ...PathSegment) []PathSegment {
+	out := make([]PathSegment, len(path), len(path)+len(seg))
+	copy(out, path)
+	return append(out, seg...)
+}
@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+type PathOrder struct {
+	Order PathOrderBody `validate:"required"`
+}
+
+type PathOrderBody struct {
+	ID       string            `validate:"required"`
+	Items    []PathItem        `validate:"slice=required"`
+	Contacts map[string]string `validate:"contacts"`
+	Tags     []string          `validate:"each=required"`
+}
+
+type PathItem struct {
+	Quantity int `validate:"min=1"`
+}
+
+func pathValidator() *Validator {
+	v := New()
+	v.AddRule("contacts", rules.Map{Value: rules.Phone{}})
+	return v
+}
+
+func TestFieldError_Paths(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       PathOrderBody
+		wantNs      string
+		wantField   string
+		wantPointer string
+	}{
+		{
+			name:        "slice element field",
+			value:       PathOrderBody{ID: "o-1", Items: []PathItem{{Quantity: 1}, {Quantity: 2}, {Quantity: 0}}},
+			wantNs:      "Order.Items[2].Quantity",
+			wantField:   "Quantity",
+			wantPointer: "/Order/Items/2/Quantity",
+		},
+		{
+			name:        "map value",
+			value:       PathOrderBody{ID: "o-1", Items: []PathItem{}, Contacts: map[string]string{"home": "x"}},
+			wantNs:      `Order.Contacts["home"]`,
+			wantField:   `Contacts["home"]`,
+			wantPointer: "/Order/Contacts/home",
+		},
+		{
+			name:        "each element",
+			value:       PathOrderBody{ID: "o-1", Items: []PathItem{}, Contacts: map[string]string{}, Tags: []string{"a", ""}},
+			wantNs:      "Order.Tags[1]",
+			wantField:   "Tags[1]",
+			wantPointer: "/Order/Tags/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fe *FieldError
+			if err := pathValidator().Validate(PathOrder{Order: tt.value}); !errors.As(err, &fe) {
+				t.Fatalf("Validate() error = %v, want *FieldError", err)
+			}
+			if fe.Namespace != tt.wantNs {
+				t.Errorf("Namespace = %q, want %q", fe.Namespace, tt.wantNs)
+			}
+			if fe.Field != tt.wantField {
+				t.Errorf("Field = %q, want %q", fe.Field, tt.wantField)
+			}
+			if fe.JSONPointer() != tt.wantPointer {
+				t.Errorf("JSONPointer() = %q, want %q", fe.JSONPointer(), tt.wantPointer)
+			}
+		})
+	}
+}
+
+func TestSetPathFormat_JSONPointer(t *testing.T) {
+	v := pathValidator()
+	v.SetPathFormat(PathJSONPointer)
+
+	err := v.Validate(PathOrder{Order: PathOrderBody{ID: "o-1", Items: []PathItem{{Quantity: 1}, {Quantity: 0}}}})
+	if err == nil || err.Error() != "/Order/Items/1/Quantity: value must be greater than or equal to 1" {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestJSONPointer_Escaping(t *testing.T) {
+	path := []PathSegment{{Field: "Labels"}, {Key: "a/b~c"}}
+	if got := jsonPointer(path); got != "/Labels/a~1b~0c" {
+		t.Errorf("jsonPointer() = %q", got)
+	}
+}
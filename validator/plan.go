@@ -0,0 +1,408 @@
+package validator
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/sgh370/goov/validator/i18n"
+	"github.com/sgh370/goov/validator/rules"
+)
+
+// structPlan is the compiled validation plan of a struct type. Plans are
+// built once per type and shared by every goroutine using the validator.
+type structPlan struct {
+	fields []fieldPlan
+	// hooks are the struct-level checks run once the fields pass.
+	hooks []StructRule
+	err   error
+}
+
+// fieldPlan holds everything needed to validate one tagged field.
+type fieldPlan struct {
+	index int
+	// name is the field as reported in error paths and label its human
+	// friendly name; see SetFieldNameFunc.
+	name  string
+	label string
+	rules []tagRule
+	// message overrides the messages of every rule of the field, from the
+	// msg tag.
+	message *i18n.Template
+	// elems is set for slices, arrays and maps of structs whose tag does not
+	// already walk the elements with dive or slice.
+	elems bool
+}
+
+// tagRule is a single resolved entry of a validate tag. Modifiers such as
+// omitempty and dive have no rule; the walker acts on them by name. For dive,
+// keys and elem hold the rules applied to map keys and to every element.
+type tagRule struct {
+	name  string
+	param string
+	rule  rules.Rule
+	keys  []tagRule
+	elem  []tagRule
+	// message overrides the message of the rule, from a message= entry
+	// following it in the tag.
+	message *i18n.Template
+	// alias is the tag name of the pattern the rule was expanded from, such
+	// as an alias of RegisterAlias.
+	alias string
+}
+
+// planKey identifies a plan: the struct type and the active validation
+// groups, as normalized by WithGroups.
+type planKey struct {
+	typ    reflect.Type
+	groups string
+}
+
+// planFor returns the plan of typ for groups, compiling it on first use. Tags
+// are parsed once per type and set of groups, so malformed parameters are
+// reported the first time the type is validated regardless of the field
+// values.
+func (v *Validator) planFor(typ reflect.Type, groups string) *structPlan {
+	key := planKey{typ: typ, groups: groups}
+	if plan, ok := v.plans.Load(key); ok {
+		return plan.(*structPlan)
+	}
+	plan, _ := v.plans.LoadOrStore(key, v.compile(typ, splitGroups(groups)))
+	return plan.(*structPlan)
+}
+
+func (v *Validator) resetPlans() {
+	v.plans.Clear()
+}
+
+func (v *Validator) compile(typ reflect.Type, groups []string) *structPlan {
+	plan := &structPlan{hooks: v.structRulesFor(typ)}
+	for i := 0; i < typ.NumField(); i++ {
+		fieldType := typ.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		resolved, err := v.fieldRules(fieldType, groups)
+		if err == nil {
+			err = checkExprs(resolved, typ, fieldType.Type)
+		}
+		if err != nil {
+			return &structPlan{err: fmt.Errorf("%s: %w", fieldType.Name, err)}
+		}
+
+		// Untagged structs and collections of structs are still walked so
+		// that rules on their own fields apply.
+		elems := hasStructElems(fieldType.Type)
+		if len(resolved) == 0 {
+			if isStruct(fieldType.Type) || elems {
+				plan.fields = append(plan.fields, fieldPlan{
+					index: i,
+					name:  v.fieldName(fieldType),
+					label: v.fieldLabel(fieldType),
+					elems: elems,
+				})
+			}
+			continue
+		}
+
+		var message *i18n.Template
+		if msg, ok := fieldType.Tag.Lookup("msg"); ok {
+			if message, err = i18n.ParseTemplate(msg); err != nil {
+				return &structPlan{err: fmt.Errorf("%s: invalid msg tag: %w", fieldType.Name, err)}
+			}
+		}
+		plan.fields = append(plan.fields, fieldPlan{
+			index:   i,
+			name:    v.fieldName(fieldType),
+			label:   v.fieldLabel(fieldType),
+			rules:   resolved,
+			message: message,
+			elems:   elems && !walksElems(resolved),
+		})
+	}
+	return plan
+}
+
+// checkExprs type-checks the expr rules of a field against the struct type
+// holding it and the type of the value each rule receives. A nil type leaves
+// its fields to evaluation.
+func checkExprs(tag []tagRule, parent, value reflect.Type) error {
+	for _, r := range tag {
+		if r.name == "dive" {
+			var key, elem reflect.Type
+			if value != nil {
+				t := value
+				if t.Kind() == reflect.Ptr {
+					t = t.Elem()
+				}
+				switch t.Kind() {
+				case reflect.Slice, reflect.Array:
+					elem = t.Elem()
+				case reflect.Map:
+					key, elem = t.Key(), t.Elem()
+				}
+			}
+			if err := checkExprs(r.keys, parent, key); err != nil {
+				return err
+			}
+			if err := checkExprs(r.elem, parent, elem); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// slice applies its rule to the elements, whose type is left to
+		// evaluation.
+		typ := value
+		if r.name == "slice" {
+			typ = nil
+		}
+		alts := []rules.Rule{r.rule}
+		if any, ok := r.rule.(anyOf); ok {
+			alts = any.rules
+		}
+		for _, rule := range alts {
+			e, ok := rule.(rules.Expr)
+			if !ok {
+				continue
+			}
+			if err := e.Program.Check(parent, typ); err != nil {
+				return fmt.Errorf("invalid expr %q: %w", e.Program, err)
+			}
+		}
+	}
+	return nil
+}
+
+func isStruct(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct
+}
+
+// hasStructElems reports whether typ is a slice, array or map, or a pointer
+// to one, whose elements are structs or pointers to structs.
+func hasStructElems(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return isStruct(typ.Elem())
+	}
+	return false
+}
+
+func walksElems(tag []tagRule) bool {
+	for _, r := range tag {
+		if r.name == "dive" || r.name == "slice" {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *Validator) parseTag(tag string) ([]tagRule, error) {
+	entries, err := lexTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	return v.resolveEntries(entries)
+}
+
+// resolveEntries resolves the entries of a tag in order. A dive entry takes
+// every entry after it as the rules for the elements.
+func (v *Validator) resolveEntries(entries [][]tagTerm) ([]tagRule, error) {
+	resolved := make([]tagRule, 0, len(entries))
+	for i, alts := range entries {
+		switch {
+		case isKeyword(alts, "dive"):
+			r, err := v.resolveDive(entries[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			return append(presenceFirst(expandPatterns(resolved)), r), nil
+		case isKeyword(alts, "keys"):
+			return nil, fmt.Errorf("keys must directly follow dive")
+		case isKeyword(alts, "endkeys"):
+			return nil, fmt.Errorf("endkeys without keys")
+		case len(alts) == 1 && alts[0].name == "message":
+			if len(resolved) == 0 || resolved[len(resolved)-1].rule == nil {
+				return nil, fmt.Errorf("message must follow a rule")
+			}
+			if alts[0].param == "" {
+				return nil, fmt.Errorf("message requires a template")
+			}
+			message, err := i18n.ParseTemplate(alts[0].param)
+			if err != nil {
+				return nil, fmt.Errorf("invalid message %q: %w", alts[0].param, err)
+			}
+			resolved[len(resolved)-1].message = message
+			continue
+		}
+
+		r, err := v.resolveAlternatives(alts)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, r)
+	}
+	return presenceFirst(expandPatterns(resolved)), nil
+}
+
+// presenceFirst moves conditional presence rules such as required_if ahead
+// of the other rules of a tag, since they decide whether the others apply
+// to an empty value.
+func presenceFirst(resolved []tagRule) []tagRule {
+	slices.SortStableFunc(resolved, func(a, b tagRule) int {
+		return cmp.Compare(presenceOrder(a), presenceOrder(b))
+	})
+	return resolved
+}
+
+func presenceOrder(r tagRule) int {
+	if _, ok := r.rule.(rules.Presence); ok {
+		return 0
+	}
+	return 1
+}
+
+// resolveDive resolves the entries following dive: an optional
+// keys...endkeys section for map keys, then the rules for each element.
+func (v *Validator) resolveDive(entries [][]tagTerm) (tagRule, error) {
+	r := tagRule{name: "dive"}
+	if len(entries) > 0 && isKeyword(entries[0], "keys") {
+		end := -1
+		for i, alts := range entries {
+			if isKeyword(alts, "endkeys") {
+				end = i
+				break
+			}
+		}
+		if end < 0 {
+			return tagRule{}, fmt.Errorf("keys without endkeys")
+		}
+		if end == 1 {
+			return tagRule{}, fmt.Errorf("keys requires at least one rule")
+		}
+
+		keys, err := v.resolveEntries(entries[1:end])
+		if err != nil {
+			return tagRule{}, err
+		}
+		r.keys = keys
+		entries = entries[end+1:]
+	}
+
+	elem, err := v.resolveEntries(entries)
+	if err != nil {
+		return tagRule{}, err
+	}
+	r.elem = elem
+	return r, nil
+}
+
+// isKeyword reports whether a tag entry is the bare keyword name.
+func isKeyword(alts []tagTerm, name string) bool {
+	return len(alts) == 1 && alts[0].name == name && !alts[0].hasParam
+}
+
+// resolveAlternatives resolves a tag entry. An entry with several
+// alternatives becomes a single rule that passes when any of them passes.
+func (v *Validator) resolveAlternatives(alts []tagTerm) (tagRule, error) {
+	if len(alts) == 1 {
+		return v.resolveRule(alts[0])
+	}
+
+	var any anyOf
+	params := make([]string, len(alts))
+	for i, term := range alts {
+		r, err := v.resolveRule(term)
+		if err != nil {
+			return tagRule{}, err
+		}
+		if r.name == "slice" || r.rule == nil {
+			return tagRule{}, fmt.Errorf("%s cannot be used as an alternative", r.name)
+		}
+		any.names = append(any.names, r.name)
+		any.rules = append(any.rules, r.rule)
+		params[i] = r.param
+	}
+	return tagRule{
+		name:  strings.Join(any.names, "|"),
+		param: strings.Join(params, "|"),
+		rule:  any,
+	}, nil
+}
+
+// resolveRule looks up a single tag term. A rule registered under the exact
+// term wins, then a factory for a parameterized term, then a rule registered
+// under the bare name, and finally a factory called without a parameter.
+func (v *Validator) resolveRule(term tagTerm) (tagRule, error) {
+	if rule, ok := v.rules[term.raw]; ok {
+		return tagRule{name: term.raw, rule: rule}, nil
+	}
+
+	name, param, hasParam := term.name, term.param, term.hasParam
+	switch name {
+	case "dive", "keys", "endkeys":
+		return tagRule{}, fmt.Errorf("%s cannot take a parameter or be used as an alternative", name)
+	case "message", "groups":
+		return tagRule{}, fmt.Errorf("%s cannot be used as an alternative", name)
+	}
+	if name == "omitempty" || name == "omitnil" {
+		// Modifiers have no rule; the walker acts on them by name.
+		if hasParam {
+			return tagRule{}, fmt.Errorf("%s takes no parameter", name)
+		}
+		return tagRule{name: name}, nil
+	}
+	if name == "slice" {
+		inner, err := v.resolveNested(param)
+		if err != nil {
+			return tagRule{}, fmt.Errorf("invalid slice parameter %q: %w", param, err)
+		}
+		return tagRule{name: name, param: param, rule: inner.rule}, nil
+	}
+
+	factory, hasFactory := v.factories[name]
+	if !hasFactory || !hasParam {
+		if rule, ok := v.rules[name]; ok {
+			return tagRule{name: name, param: param, rule: rule}, nil
+		}
+	}
+	if !hasFactory {
+		return tagRule{}, fmt.Errorf("unknown validation rule: %s", name)
+	}
+
+	rule, err := factory(param)
+	if err != nil {
+		return tagRule{}, fmt.Errorf("invalid %s parameter %q: %w", name, param, err)
+	}
+	return tagRule{name: name, param: param, rule: rule}, nil
+}
+
+// resolveNested resolves the parameter of rules such as "slice" and "each",
+// which names a single rule, possibly with its own parameter or alternatives.
+// An alias stays a single *Pattern rule here.
+func (v *Validator) resolveNested(param string) (tagRule, error) {
+	entries, err := lexTag(param)
+	if err != nil {
+		return tagRule{}, err
+	}
+	if len(entries) != 1 {
+		return tagRule{}, fmt.Errorf("expected a single rule")
+	}
+	inner, err := v.resolveAlternatives(entries[0])
+	if err != nil {
+		return tagRule{}, err
+	}
+	if inner.rule == nil {
+		return tagRule{}, fmt.Errorf("expected a single rule")
+	}
+	return inner, nil
+}
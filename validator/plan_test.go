@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+type PlanUser struct {
+	Name     string `validate:"required,length=2:10"`
+	Nickname string
+	internal string `validate:"required"`
+	Age      int    `validate:"adult"`
+}
+
+func TestPlanFor_CompilesTaggedFields(t *testing.T) {
+	v := New()
+	v.AddRule("adult", rules.Min{Value: 18})
+
+	plan := v.planFor(reflect.TypeOf(PlanUser{}), "")
+	if plan.err != nil {
+		t.Fatalf("planFor() error = %v", plan.err)
+	}
+	if len(plan.fields) != 2 {
+		t.Fatalf("got %d fields, want 2", len(plan.fields))
+	}
+
+	name := plan.fields[0]
+	if name.index != 0 || name.name != "Name" || len(name.rules) != 2 {
+		t.Errorf("Name plan = %+v", name)
+	}
+	if length, ok := name.rules[1].rule.(rules.Length); !ok || length.Min != 2 || length.Max != 10 {
+		t.Errorf("length rule = %#v, want parsed Length{2, 10}", name.rules[1].rule)
+	}
+	if age := plan.fields[1]; age.index != 3 || age.name != "Age" {
+		t.Errorf("Age plan = %+v", age)
+	}
+
+	if again := v.planFor(reflect.TypeOf(PlanUser{}), ""); again != plan {
+		t.Error("planFor() did not return the cached plan")
+	}
+}
+
+func TestPlanFor_ResetOnAddRule(t *testing.T) {
+	v := New()
+	u := PlanUser{Name: "john", Age: 16}
+
+	if err := v.Validate(u); err == nil {
+		t.Fatal("Validate() expected unknown rule error")
+	}
+
+	v.AddRule("adult", rules.Min{Value: 18})
+	if err := v.Validate(u); err == nil || err.Error() != "Age: value must be greater than or equal to 18" {
+		t.Errorf("Validate() error = %v, want min error after AddRule", err)
+	}
+}
+
+func TestValidate_Concurrent(t *testing.T) {
+	v := New()
+	v.AddRule("adult", rules.Min{Value: 18})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			u := PlanUser{Name: "john", Age: 18 + i%2*-5}
+			err := v.Validate(&u)
+			if wantErr := i%2 == 1; (err != nil) != wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, wantErr)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,98 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type PresenceContact struct {
+	ContactMethod string `validate:"required,oneof=email phone"`
+	Email         string `validate:"email,required_if=ContactMethod email"`
+	Phone         string `validate:"phone,required_if=ContactMethod phone"`
+	IsCompany     bool
+	VATNumber     string `validate:"excluded_unless=IsCompany true"`
+	Website       string `validate:"url,required_with=VATNumber"`
+	Fax           string `validate:"required_without_all=Email Phone,excluded_with=Email"`
+}
+
+func TestValidate_PresenceTags(t *testing.T) {
+	tests := []struct {
+		name  string
+		value PresenceContact
+		want  []string
+	}{
+		{"email contact", PresenceContact{ContactMethod: "email", Email: "a@b.co"}, nil},
+		{"missing email", PresenceContact{ContactMethod: "email"}, []string{"Email", "Fax"}},
+		{"phone contact", PresenceContact{ContactMethod: "phone", Phone: "+4912345678"}, nil},
+		{"missing phone", PresenceContact{ContactMethod: "phone", Email: "a@b.co"}, []string{"Phone"}},
+		{"present values still validated", PresenceContact{ContactMethod: "phone", Phone: "+4912345678", Email: "nope"}, []string{"Email"}},
+		{"excluded unless company", PresenceContact{ContactMethod: "email", Email: "a@b.co", VATNumber: "DE123"}, []string{"VATNumber", "Website"}},
+		{"company", PresenceContact{ContactMethod: "email", Email: "a@b.co", IsCompany: true, VATNumber: "DE123", Website: "https://a.co"}, nil},
+		{"excluded with", PresenceContact{ContactMethod: "email", Email: "a@b.co", Fax: "123"}, []string{"Fax"}},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := namespaces(v.ValidateAll(tt.value))
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ValidateAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_PresenceErrors(t *testing.T) {
+	v := New()
+
+	var verrs ValidationErrors
+	err := v.Validate(PresenceContact{ContactMethod: "phone", Email: "a@b.co"})
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+	}
+	fe := verrs[0]
+	if fe.Rule != "required_if" || fe.Param != "ContactMethod phone" || fe.Message != "value is required when ContactMethod is phone" {
+		t.Errorf("FieldError = %+v", fe)
+	}
+
+	for _, tag := range []string{"required_if", "required_if=ContactMethod", "excluded_with"} {
+		if err := v.Var("x", tag); err == nil || errors.As(err, &verrs) {
+			t.Errorf("Var(%q) error = %v, want a tag error", tag, err)
+		}
+	}
+}
+
+func TestValidateMap_PresenceTags(t *testing.T) {
+	schema := map[string]interface{}{
+		"type":  "required",
+		"phone": "required_if=type phone",
+		"seats": "required_if=type team,min=1",
+	}
+
+	tests := []struct {
+		name string
+		doc  map[string]interface{}
+		want string
+	}{
+		{"phone", map[string]interface{}{"type": "phone"}, "phone"},
+		{"team", map[string]interface{}{"type": "team"}, "seats"},
+		{"team seats", map[string]interface{}{"type": "team", "seats": 0.0}, "seats"},
+		{"other", map[string]interface{}{"type": "email"}, ""},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateMap(tt.doc, schema)
+			var fe *FieldError
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("ValidateMap() unexpected error = %v", err)
+				}
+			} else if !errors.As(err, &fe) || fe.Namespace != tt.want {
+				t.Errorf("ValidateMap() error = %v, want an error on %s", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package validator
+
+import "testing"
+
+type RecurseContact struct {
+	Type  string `validate:"required,oneof=email phone"`
+	Value string `validate:"required"`
+}
+
+type RecurseAddress struct {
+	City string `validate:"required"`
+}
+
+func TestValidate_RecursesIntoCollections(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		namespace string
+	}{
+		{"map value", struct {
+			Contacts map[string]RecurseContact
+		}{map[string]RecurseContact{"home": {Type: "email", Value: "a@example.com"}, "work": {Type: "fax", Value: "1"}}}, `Contacts["work"].Type`},
+		{"array element", struct {
+			Stops [2]RecurseAddress
+		}{[2]RecurseAddress{{City: "Berlin"}, {}}}, "Stops[1].City"},
+		{"pointer element", struct {
+			Backups []*RecurseAddress
+		}{[]*RecurseAddress{nil, {}}}, "Backups[1].City"},
+		{"map of pointers", struct {
+			ByPostal map[int]*RecurseAddress `validate:"required"`
+		}{map[int]*RecurseAddress{10115: {City: "Berlin"}, 75001: {}}}, "ByPostal[75001].City"},
+		{"tagged map", struct {
+			Tagged map[string]RecurseAddress `validate:"dive,keys,length=2,endkeys"`
+		}{map[string]RecurseAddress{"fr": {}}}, `Tagged["fr"].City`},
+		{"map of strings", struct {
+			Ignored map[string]string
+		}{map[string]string{"x": ""}}, ""},
+		{"unexported field", struct {
+			unchecked []RecurseAddress
+		}{[]RecurseAddress{{}}}, ""},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := namespaces(v.ValidateAll(tt.value))
+			if tt.namespace == "" {
+				if len(got) != 0 {
+					t.Errorf("ValidateAll() = %v, want no errors", got)
+				}
+				return
+			}
+			if len(got) != 1 || got[0] != tt.namespace {
+				t.Errorf("ValidateAll() = %v, want [%s]", got, tt.namespace)
+			}
+		})
+	}
+}
+
+func TestValidateAll_MapKeyOrder(t *testing.T) {
+	type Order struct {
+		Contacts map[string]RecurseContact
+		ByPostal map[int]*RecurseAddress
+	}
+	o := Order{
+		Contacts: map[string]RecurseContact{
+			"work":   {Type: "email"},
+			"home":   {Type: "email"},
+			"mobile": {Type: "email"},
+		},
+		ByPostal: map[int]*RecurseAddress{100: {}, 20: {}, 3: {}},
+	}
+
+	want := []string{
+		`Contacts["home"].Value`,
+		`Contacts["mobile"].Value`,
+		`Contacts["work"].Value`,
+		"ByPostal[3].City",
+		"ByPostal[20].City",
+		"ByPostal[100].City",
+	}
+
+	v := New()
+	for run := 0; run < 10; run++ {
+		got := namespaces(v.ValidateAll(o))
+		if len(got) != len(want) {
+			t.Fatalf("ValidateAll() = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("run %d: error %d = %q, want %q", run, i, got[i], want[i])
+			}
+		}
+	}
+}
@@ -1,13 +1,34 @@
 package rules
 
 import (
-	"fmt"
+	"context"
 	"net"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
+// Patterns are compiled once at package initialization rather than on every
+// call to Validate.
+var (
+	domainLabelRegex   = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`)
+	numericRegex       = regexp.MustCompile(`^[0-9]+$`)
+	upperRegex         = regexp.MustCompile(`[A-Z]`)
+	lowerRegex         = regexp.MustCompile(`[a-z]`)
+	digitRegex         = regexp.MustCompile(`[0-9]`)
+	specialRegex       = regexp.MustCompile(`[^a-zA-Z0-9]`)
+	cardSeparatorRegex = regexp.MustCompile(`[\s-]`)
+	cardNumberRegex    = regexp.MustCompile(`^[0-9]{13,19}$`)
+	macSeparatorRegex  = regexp.MustCompile(`[:-]`)
+	macRegex           = regexp.MustCompile(`^[0-9A-Fa-f]{12}$`)
+	hexColorRegex      = regexp.MustCompile(`^#([0-9a-f]{3}|[0-9a-f]{6})$`)
+	rgbColorRegex      = regexp.MustCompile(`^rgb\((\d{1,3}),\s*(\d{1,3}),\s*(\d{1,3})\)$`)
+	hslColorRegex      = regexp.MustCompile(`^hsl\((\d{1,3}),\s*(\d{1,3})%,\s*(\d{1,3})%\)$`)
+	emailRegex         = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	hostnameRegex      = regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+	semVerIdentRegex   = regexp.MustCompile(`^[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*$`)
+)
+
 // IP validates IP addresses (v4 or v6)
 type IP struct {
 	// AllowV4 allows IPv4 addresses
@@ -21,27 +42,27 @@ type IP struct {
 func (i IP) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("ip.not_string", nil)
 	}
 
 	if str == "" {
 		if i.AllowEmpty {
 			return nil
 		}
-		return fmt.Errorf("value is required")
+		return newError("ip.required", nil)
 	}
 
 	ip := net.ParseIP(str)
 	if ip == nil {
-		return fmt.Errorf("invalid IP address format")
+		return newError("ip.invalid", nil)
 	}
 
 	ipv4 := ip.To4() != nil
 	if ipv4 && !i.AllowV4 {
-		return fmt.Errorf("IPv4 addresses are not allowed")
+		return newError("ip.v4_not_allowed", nil)
 	}
 	if !ipv4 && !i.AllowV6 {
-		return fmt.Errorf("IPv6 addresses are not allowed")
+		return newError("ip.v6_not_allowed", nil)
 	}
 
 	return nil
@@ -58,14 +79,14 @@ type Domain struct {
 func (d Domain) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("domain.not_string", nil)
 	}
 
 	if str == "" {
 		if d.AllowEmpty {
 			return nil
 		}
-		return fmt.Errorf("value is required")
+		return newError("domain.required", nil)
 	}
 
 	// Domain name validation rules:
@@ -76,30 +97,30 @@ func (d Domain) Validate(value interface{}) error {
 	// 5. TLD cannot be all numeric
 
 	if len(str) > 253 {
-		return fmt.Errorf("domain name too long")
+		return newError("domain.too_long", nil)
 	}
 
 	labels := strings.Split(str, ".")
 	if len(labels) < 2 {
-		return fmt.Errorf("invalid domain name format")
+		return newError("domain.invalid", nil)
 	}
 
 	if !d.AllowSubdomains && len(labels) > 2 {
-		return fmt.Errorf("subdomains are not allowed")
+		return newError("domain.subdomains_not_allowed", nil)
 	}
 
 	for i, label := range labels {
 		if len(label) == 0 {
-			return fmt.Errorf("invalid domain name format")
+			return newError("domain.invalid", nil)
 		}
 		if len(label) > 63 {
-			return fmt.Errorf("domain name too long")
+			return newError("domain.too_long", nil)
 		}
-		if !regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`).MatchString(label) {
-			return fmt.Errorf("invalid domain name format")
+		if !domainLabelRegex.MatchString(label) {
+			return newError("domain.invalid", nil)
 		}
-		if i == len(labels)-1 && regexp.MustCompile(`^[0-9]+$`).MatchString(label) {
-			return fmt.Errorf("TLD cannot be all numeric")
+		if i == len(labels)-1 && numericRegex.MatchString(label) {
+			return newError("domain.numeric_tld", nil)
 		}
 	}
 
@@ -125,27 +146,27 @@ type Password struct {
 func (p Password) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("password.not_string", nil)
 	}
 
 	if len(str) < p.MinLength {
-		return fmt.Errorf("password must be at least %d characters", p.MinLength)
+		return newError("password.min_length", Params{"min": p.MinLength, "max": p.MaxLength})
 	}
 	if p.MaxLength > 0 && len(str) > p.MaxLength {
-		return fmt.Errorf("password must not exceed %d characters", p.MaxLength)
+		return newError("password.max_length", Params{"min": p.MinLength, "max": p.MaxLength})
 	}
 
-	if p.RequireUpper && !regexp.MustCompile(`[A-Z]`).MatchString(str) {
-		return fmt.Errorf("password must contain at least one uppercase letter")
+	if p.RequireUpper && !upperRegex.MatchString(str) {
+		return newError("password.upper", nil)
 	}
-	if p.RequireLower && !regexp.MustCompile(`[a-z]`).MatchString(str) {
-		return fmt.Errorf("password must contain at least one lowercase letter")
+	if p.RequireLower && !lowerRegex.MatchString(str) {
+		return newError("password.lower", nil)
 	}
-	if p.RequireDigit && !regexp.MustCompile(`[0-9]`).MatchString(str) {
-		return fmt.Errorf("password must contain at least one digit")
+	if p.RequireDigit && !digitRegex.MatchString(str) {
+		return newError("password.digit", nil)
 	}
-	if p.RequireSpecial && !regexp.MustCompile(`[^a-zA-Z0-9]`).MatchString(str) {
-		return fmt.Errorf("password must contain at least one special character")
+	if p.RequireSpecial && !specialRegex.MatchString(str) {
+		return newError("password.special", nil)
 	}
 
 	return nil
@@ -160,21 +181,21 @@ type CreditCard struct {
 func (c CreditCard) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("creditcard.not_string", nil)
 	}
 
 	if str == "" {
 		if c.AllowEmpty {
 			return nil
 		}
-		return fmt.Errorf("value is required")
+		return newError("creditcard.required", nil)
 	}
 
 	// Remove spaces and hyphens
-	str = regexp.MustCompile(`[\s-]`).ReplaceAllString(str, "")
+	str = cardSeparatorRegex.ReplaceAllString(str, "")
 
-	if !regexp.MustCompile(`^[0-9]{13,19}$`).MatchString(str) {
-		return fmt.Errorf("invalid credit card number format")
+	if !cardNumberRegex.MatchString(str) {
+		return newError("creditcard.invalid", nil)
 	}
 
 	// Luhn algorithm
@@ -194,7 +215,7 @@ func (c CreditCard) Validate(value interface{}) error {
 	}
 
 	if sum%10 != 0 {
-		return fmt.Errorf("invalid credit card number format")
+		return newError("creditcard.invalid", nil)
 	}
 
 	return nil
@@ -209,19 +230,19 @@ type CIDR struct {
 func (c CIDR) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("cidr.not_string", nil)
 	}
 
 	if str == "" {
 		if c.AllowEmpty {
 			return nil
 		}
-		return fmt.Errorf("value is required")
+		return newError("cidr.required", nil)
 	}
 
 	_, _, err := net.ParseCIDR(str)
 	if err != nil {
-		return fmt.Errorf("invalid CIDR format")
+		return newError("cidr.invalid", nil)
 	}
 
 	return nil
@@ -236,21 +257,21 @@ type MAC struct {
 func (m MAC) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("mac.not_string", nil)
 	}
 
 	if str == "" {
 		if m.AllowEmpty {
 			return nil
 		}
-		return fmt.Errorf("value is required")
+		return newError("mac.required", nil)
 	}
 
 	// Remove colons and hyphens
-	str = regexp.MustCompile(`[:-]`).ReplaceAllString(str, "")
+	str = macSeparatorRegex.ReplaceAllString(str, "")
 
-	if !regexp.MustCompile(`^[0-9A-Fa-f]{12}$`).MatchString(str) {
-		return fmt.Errorf("invalid MAC address format")
+	if !macRegex.MatchString(str) {
+		return newError("mac.invalid", nil)
 	}
 
 	return nil
@@ -265,30 +286,30 @@ type LatLong struct {
 func (l LatLong) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("latlong.not_string", nil)
 	}
 
 	if str == "" {
 		if l.AllowEmpty {
 			return nil
 		}
-		return fmt.Errorf("value is required")
+		return newError("latlong.required", nil)
 	}
 
 	// Format: "latitude,longitude"
 	parts := strings.Split(str, ",")
 	if len(parts) != 2 {
-		return fmt.Errorf("invalid lat/long format, expected 'latitude,longitude'")
+		return newError("latlong.invalid", nil)
 	}
 
 	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
 	if err != nil || lat < -90 || lat > 90 {
-		return fmt.Errorf("invalid latitude value")
+		return newError("latlong.latitude", nil)
 	}
 
 	long, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
 	if err != nil || long < -180 || long > 180 {
-		return fmt.Errorf("invalid longitude value")
+		return newError("latlong.longitude", nil)
 	}
 
 	return nil
@@ -309,26 +330,26 @@ type Color struct {
 func (c Color) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("color.not_string", nil)
 	}
 
 	if str == "" {
 		if c.AllowEmpty {
 			return nil
 		}
-		return fmt.Errorf("value is required")
+		return newError("color.required", nil)
 	}
 
 	str = strings.TrimSpace(strings.ToLower(str))
 
 	if c.AllowHEX {
-		if regexp.MustCompile(`^#([0-9a-f]{3}|[0-9a-f]{6})$`).MatchString(str) {
+		if hexColorRegex.MatchString(str) {
 			return nil
 		}
 	}
 
 	if c.AllowRGB {
-		if match := regexp.MustCompile(`^rgb\((\d{1,3}),\s*(\d{1,3}),\s*(\d{1,3})\)$`).FindStringSubmatch(str); match != nil {
+		if match := rgbColorRegex.FindStringSubmatch(str); match != nil {
 			r, _ := strconv.Atoi(match[1])
 			g, _ := strconv.Atoi(match[2])
 			b, _ := strconv.Atoi(match[3])
@@ -339,7 +360,7 @@ func (c Color) Validate(value interface{}) error {
 	}
 
 	if c.AllowHSL {
-		if match := regexp.MustCompile(`^hsl\((\d{1,3}),\s*(\d{1,3})%,\s*(\d{1,3})%\)$`).FindStringSubmatch(str); match != nil {
+		if match := hslColorRegex.FindStringSubmatch(str); match != nil {
 			h, _ := strconv.Atoi(match[1])
 			s, _ := strconv.Atoi(match[2])
 			l, _ := strconv.Atoi(match[3])
@@ -349,7 +370,7 @@ func (c Color) Validate(value interface{}) error {
 		}
 	}
 
-	return fmt.Errorf("invalid color format")
+	return newError("color.invalid", nil)
 }
 
 // EmailDNS validates email addresses and optionally checks DNS records
@@ -361,29 +382,37 @@ type EmailDNS struct {
 }
 
 func (e EmailDNS) Validate(value interface{}) error {
+	return e.ValidateCtx(context.Background(), value)
+}
+
+// ValidateCtx bounds the MX lookup by ctx. If ctx is done before the lookup
+// completes, the context error is returned as is.
+func (e EmailDNS) ValidateCtx(ctx context.Context, value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("email.not_string", nil)
 	}
 
 	if str == "" {
 		if e.AllowEmpty {
 			return nil
 		}
-		return fmt.Errorf("value is required")
+		return newError("email.required", nil)
 	}
 
 	// Basic email format validation
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	if !emailRegex.MatchString(str) {
-		return fmt.Errorf("invalid email format")
+		return newError("email.invalid", nil)
 	}
 
 	if e.CheckDNS {
 		parts := strings.Split(str, "@")
-		_, err := net.LookupMX(parts[1])
+		_, err := net.DefaultResolver.LookupMX(ctx, parts[1])
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		if err != nil {
-			return fmt.Errorf("domain does not have valid MX records")
+			return newError("email.no_mx", nil)
 		}
 	}
 
@@ -401,14 +430,14 @@ type Hostname struct {
 func (h Hostname) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("hostname.not_string", nil)
 	}
 
 	if str == "" {
 		if h.AllowEmpty {
 			return nil
 		}
-		return fmt.Errorf("value is required")
+		return newError("hostname.required", nil)
 	}
 
 	if h.AllowWildcard && strings.HasPrefix(str, "*.") {
@@ -417,12 +446,11 @@ func (h Hostname) Validate(value interface{}) error {
 
 	// RFC 1123 hostname validation
 	if len(str) > 255 {
-		return fmt.Errorf("hostname too long")
+		return newError("hostname.too_long", nil)
 	}
 
-	hostnameRegex := regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
 	if !hostnameRegex.MatchString(str) {
-		return fmt.Errorf("invalid hostname format")
+		return newError("hostname.invalid", nil)
 	}
 
 	return nil
@@ -457,11 +485,11 @@ func (p Port) Validate(value interface{}) error {
 			if p.AllowEmpty {
 				return nil
 			}
-			return fmt.Errorf("value is required")
+			return newError("port.required", nil)
 		}
 		port, err := strconv.Atoi(str)
 		if err != nil {
-			return fmt.Errorf("invalid port number")
+			return newError("port.invalid", nil)
 		}
 		value = port
 	}
@@ -469,11 +497,11 @@ func (p Port) Validate(value interface{}) error {
 	// Handle numeric input
 	port, ok := value.(int)
 	if !ok {
-		return fmt.Errorf("value must be a string or integer")
+		return newError("port.type", nil)
 	}
 
 	if port < p.Min || port > p.Max {
-		return fmt.Errorf("port must be between %d and %d", p.Min, p.Max)
+		return newError("port.range", Params{"min": p.Min, "max": p.Max})
 	}
 
 	return nil
@@ -496,24 +524,24 @@ type SemVer struct {
 func (s SemVer) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("semver.not_string", nil)
 	}
 
 	if str == "" {
 		if s.AllowEmpty {
 			return nil
 		}
-		return fmt.Errorf("value is required")
+		return newError("semver.required", nil)
 	}
 
 	// Handle v prefix
 	if strings.HasPrefix(str, "v") {
 		if !s.AllowPrefix {
-			return fmt.Errorf("v prefix not allowed")
+			return newError("semver.prefix_not_allowed", nil)
 		}
 		str = str[1:]
 	} else if s.RequirePrefix && s.AllowPrefix {
-		return fmt.Errorf("v prefix is required")
+		return newError("semver.prefix_required", nil)
 	}
 
 	// Split version into parts
@@ -523,32 +551,32 @@ func (s SemVer) Validate(value interface{}) error {
 	// Validate core version (X.Y.Z)
 	core := strings.Split(versionParts[0], ".")
 	if len(core) != 3 {
-		return fmt.Errorf("version must be in format X.Y.Z")
+		return newError("semver.format", nil)
 	}
 
 	for _, num := range core {
-		if !regexp.MustCompile(`^\d+$`).MatchString(num) {
-			return fmt.Errorf("version components must be numeric")
+		if !numericRegex.MatchString(num) {
+			return newError("semver.not_numeric", nil)
 		}
 	}
 
 	// Validate prerelease
 	if len(versionParts) > 1 {
 		if !s.AllowPrerelease {
-			return fmt.Errorf("prerelease versions not allowed")
+			return newError("semver.prerelease_not_allowed", nil)
 		}
-		if !regexp.MustCompile(`^[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*$`).MatchString(versionParts[1]) {
-			return fmt.Errorf("invalid prerelease format")
+		if !semVerIdentRegex.MatchString(versionParts[1]) {
+			return newError("semver.invalid_prerelease", nil)
 		}
 	}
 
 	// Validate build metadata
 	if len(parts) > 1 {
 		if !s.AllowBuild {
-			return fmt.Errorf("build metadata not allowed")
+			return newError("semver.build_not_allowed", nil)
 		}
-		if !regexp.MustCompile(`^[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*$`).MatchString(parts[1]) {
-			return fmt.Errorf("invalid build metadata format")
+		if !semVerIdentRegex.MatchString(parts[1]) {
+			return newError("semver.invalid_build", nil)
 		}
 	}
 
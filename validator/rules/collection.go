@@ -1,35 +1,64 @@
 package rules
 
 import (
-	"fmt"
 	"reflect"
+	"unicode/utf8"
 )
 
 type Rule interface {
 	Validate(value interface{}) error
 }
 
+// Length ensures a string has between Min and Max characters, or a slice,
+// array or map between Min and Max elements. A Max of 0 means no upper bound.
 type Length struct {
 	Min int
 	Max int
 }
 
 func (l Length) Validate(value interface{}) error {
+	length, ok := lengthOf(value)
+	if !ok {
+		return newError("length.type", nil)
+	}
+	if length < l.Min {
+		return newError("length.min", Params{"min": l.Min, "max": l.Max})
+	}
+	if l.Max > 0 && length > l.Max {
+		return newError("length.max", Params{"min": l.Min, "max": l.Max})
+	}
+	return nil
+}
+
+// ExactLength ensures a string has exactly Value characters, or a slice,
+// array or map exactly Value elements. Unlike Length, a Value of 0 requires
+// an empty value.
+type ExactLength struct {
+	Value int
+}
+
+func (e ExactLength) Validate(value interface{}) error {
+	length, ok := lengthOf(value)
+	if !ok {
+		return newError("length.type", nil)
+	}
+	if length != e.Value {
+		return newError("length.exact", Params{"length": e.Value})
+	}
+	return nil
+}
+
+// lengthOf returns the number of characters of a string or elements of a
+// slice, array or map.
+func lengthOf(value interface{}) (int, bool) {
 	v := reflect.ValueOf(value)
-	
 	switch v.Kind() {
-	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
-		length := v.Len()
-		if length < l.Min {
-			return fmt.Errorf("length must be at least %d", l.Min)
-		}
-		if l.Max > 0 && length > l.Max {
-			return fmt.Errorf("length must not exceed %d", l.Max)
-		}
-		return nil
-	default:
-		return fmt.Errorf("value must be a slice, array, map, or string")
+	case reflect.String:
+		return utf8.RuneCountInString(v.String()), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), true
 	}
+	return 0, false
 }
 
 type Each struct {
@@ -40,12 +69,12 @@ func (e Each) Validate(value interface{}) error {
 	v := reflect.ValueOf(value)
 	
 	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
-		return fmt.Errorf("value must be a slice or array")
+		return newError("each.type", nil)
 	}
 
 	for i := 0; i < v.Len(); i++ {
 		if err := e.Rule.Validate(v.Index(i).Interface()); err != nil {
-			return fmt.Errorf("item at index %d: %v", i, err)
+			return wrapElement("each", i, err)
 		}
 	}
 	return nil
@@ -59,7 +88,7 @@ func (c Contains) Validate(value interface{}) error {
 	v := reflect.ValueOf(value)
 	
 	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
-		return fmt.Errorf("value must be a slice or array")
+		return newError("contains.type", nil)
 	}
 
 	for i := 0; i < v.Len(); i++ {
@@ -67,7 +96,7 @@ func (c Contains) Validate(value interface{}) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("value must contain %v", c.Value)
+	return newError("contains.missing", Params{"value": c.Value})
 }
 
 type Unique struct{}
@@ -76,14 +105,14 @@ func (u Unique) Validate(value interface{}) error {
 	v := reflect.ValueOf(value)
 	
 	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
-		return fmt.Errorf("value must be a slice or array")
+		return newError("unique.type", nil)
 	}
 
 	seen := make(map[interface{}]bool)
 	for i := 0; i < v.Len(); i++ {
 		item := v.Index(i).Interface()
 		if seen[item] {
-			return fmt.Errorf("duplicate value found: %v", item)
+			return newError("unique.duplicate", Params{"value": item})
 		}
 		seen[item] = true
 	}
@@ -97,24 +126,24 @@ type Map struct {
 
 func (m Map) Validate(value interface{}) error {
 	if value == nil {
-		return fmt.Errorf("map is nil")
+		return newError("map.nil", nil)
 	}
 
 	v := reflect.ValueOf(value)
 	if v.Kind() != reflect.Map {
-		return fmt.Errorf("value is not a map")
+		return newError("map.type", nil)
 	}
 
-	for _, key := range v.MapKeys() {
+	for _, key := range SortedKeys(v) {
 		if m.Key != nil {
 			if err := m.Key.Validate(key.Interface()); err != nil {
-				return fmt.Errorf("invalid map key: %v", err)
+				return wrapKey("map", key.Interface(), err)
 			}
 		}
 
 		if m.Value != nil {
 			if err := m.Value.Validate(v.MapIndex(key).Interface()); err != nil {
-				return fmt.Errorf("invalid map value for key %v: %v", key.Interface(), err)
+				return wrapElement("map", key.Interface(), err)
 			}
 		}
 	}
@@ -128,19 +157,19 @@ type Slice struct {
 
 func (s Slice) Validate(value interface{}) error {
 	if value == nil {
-		return fmt.Errorf("slice is nil")
+		return newError("slice.nil", nil)
 	}
 
 	v := reflect.ValueOf(value)
 	if v.Kind() != reflect.Slice {
-		return fmt.Errorf("value is not a slice")
+		return newError("slice.type", nil)
 	}
 
 	for i := 0; i < v.Len(); i++ {
 		item := v.Index(i)
 		if s.Rule != nil {
 			if err := s.Rule.Validate(item.Interface()); err != nil {
-				return fmt.Errorf("invalid item at index %d: %v", i, err)
+				return wrapElement("slice", i, err)
 			}
 		}
 	}
@@ -156,14 +185,14 @@ func (e EachMulti) Validate(value interface{}) error {
 	v := reflect.ValueOf(value)
 	
 	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
-		return fmt.Errorf("value must be a slice or array")
+		return newError("each_multi.type", nil)
 	}
 
 	for i := 0; i < v.Len(); i++ {
 		item := v.Index(i).Interface()
 		for _, rule := range e.Rules {
 			if err := rule.Validate(item); err != nil {
-				return fmt.Errorf("item at index %d failed validation: %v", i, err)
+				return wrapElement("each_multi", i, err)
 			}
 		}
 	}
@@ -178,14 +207,14 @@ type Keys struct {
 func (k Keys) Validate(value interface{}) error {
 	v := reflect.ValueOf(value)
 	if v.Kind() != reflect.Map {
-		return fmt.Errorf("value must be a map")
+		return newError("keys.type", nil)
 	}
 
-	keys := v.MapKeys()
+	keys := SortedKeys(v)
 	for _, rule := range k.Rules {
 		for _, key := range keys {
 			if err := rule.Validate(key.Interface()); err != nil {
-				return fmt.Errorf("map key %v failed validation: %v", key.Interface(), err)
+				return wrapKey("keys", key.Interface(), err)
 			}
 		}
 	}
@@ -6,9 +6,16 @@ import (
 	"net/url"
 	"reflect"
 	"regexp"
+	"strings"
 	"time"
 )
 
+var (
+	phoneRegex    = regexp.MustCompile(`^\+?\d{10,15}$`)
+	alphanumRegex = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+	uuidRegex     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
 type TimeFormat struct {
 	Layout string
 }
@@ -16,12 +23,12 @@ type TimeFormat struct {
 func (t TimeFormat) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("time.not_string", nil)
 	}
 
 	_, err := time.Parse(t.Layout, str)
 	if err != nil {
-		return fmt.Errorf("invalid time format: must match layout %s", t.Layout)
+		return newError("time.layout", Params{"layout": t.Layout})
 	}
 	return nil
 }
@@ -33,12 +40,12 @@ type URL struct {
 func (u URL) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("url.not_string", nil)
 	}
 
 	parsed, err := url.Parse(str)
 	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
-		return fmt.Errorf("invalid URL format")
+		return newError("url.invalid", nil)
 	}
 
 	if len(u.AllowedSchemes) > 0 {
@@ -50,7 +57,7 @@ func (u URL) Validate(value interface{}) error {
 			}
 		}
 		if !valid {
-			return fmt.Errorf("URL scheme must be one of: %v", u.AllowedSchemes)
+			return newError("url.scheme", Params{"schemes": u.AllowedSchemes})
 		}
 	}
 	return nil
@@ -61,12 +68,12 @@ type JSON struct{}
 func (j JSON) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value must be a string")
+		return newError("json.not_string", nil)
 	}
 
 	var js interface{}
 	if err := json.Unmarshal([]byte(str), &js); err != nil {
-		return fmt.Errorf("invalid JSON format")
+		return newError("json.invalid", nil)
 	}
 	return nil
 }
@@ -80,8 +87,36 @@ func (o OneOf) Validate(value interface{}) error {
 		if reflect.DeepEqual(value, v) {
 			return nil
 		}
+		// Values parsed from a tag are strings, so compare them against
+		// the textual form of non-string values such as ints.
+		if s, ok := v.(string); ok {
+			if _, isStr := value.(string); !isStr && value != nil && fmt.Sprint(value) == s {
+				return nil
+			}
+		}
 	}
-	return fmt.Errorf("value must be one of: %v", o.Values)
+	return newError("oneof", Params{"values": o.Values})
+}
+
+// Regex validates that a string matches a regular expression
+type Regex struct {
+	Pattern *regexp.Regexp
+}
+
+func (r Regex) Validate(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return newError("regex.not_string", nil)
+	}
+
+	if r.Pattern == nil {
+		return newError("regex.no_pattern", nil)
+	}
+
+	if !r.Pattern.MatchString(str) {
+		return newError("regex", Params{"pattern": r.Pattern.String()})
+	}
+	return nil
 }
 
 type Custom struct {
@@ -100,7 +135,7 @@ type Phone struct {
 func (p Phone) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("expected string, got %T", value)
+		return newError("phone.not_string", Params{"type": fmt.Sprintf("%T", value)})
 	}
 
 	if str == "" && p.AllowEmpty {
@@ -108,9 +143,38 @@ func (p Phone) Validate(value interface{}) error {
 	}
 
 	// Basic phone validation: +1234567890 or 1234567890
-	matched, _ := regexp.MatchString(`^\+?\d{10,15}$`, str)
-	if !matched {
-		return fmt.Errorf("invalid phone number format")
+	if !phoneRegex.MatchString(str) {
+		return newError("phone.invalid", nil)
+	}
+	return nil
+}
+
+// Alphanum validates that a string consists of ASCII letters and digits only
+type Alphanum struct{}
+
+func (a Alphanum) Validate(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return newError("alphanum.not_string", Params{"type": fmt.Sprintf("%T", value)})
+	}
+
+	if !alphanumRegex.MatchString(str) {
+		return newError("alphanum", nil)
+	}
+	return nil
+}
+
+// Lowercase validates that a string has no uppercase letters
+type Lowercase struct{}
+
+func (l Lowercase) Validate(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return newError("lowercase.not_string", Params{"type": fmt.Sprintf("%T", value)})
+	}
+
+	if str != strings.ToLower(str) {
+		return newError("lowercase", nil)
 	}
 	return nil
 }
@@ -121,12 +185,11 @@ type UUID struct{}
 func (u UUID) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("expected string, got %T", value)
+		return newError("uuid.not_string", Params{"type": fmt.Sprintf("%T", value)})
 	}
 
-	matched, _ := regexp.MatchString(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`, str)
-	if !matched {
-		return fmt.Errorf("invalid UUID format")
+	if !uuidRegex.MatchString(str) {
+		return newError("uuid.invalid", nil)
 	}
 	return nil
 }
@@ -142,7 +205,7 @@ type Date struct {
 func (d Date) Validate(value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("expected string, got %T", value)
+		return newError("date.not_string", Params{"type": fmt.Sprintf("%T", value)})
 	}
 
 	if str == "" && d.AllowEmpty {
@@ -151,15 +214,15 @@ func (d Date) Validate(value interface{}) error {
 
 	t, err := time.Parse(d.Format, str)
 	if err != nil {
-		return fmt.Errorf("invalid date format: %v", err)
+		return newError("date.invalid", Params{"error": err})
 	}
 
 	if !d.Min.IsZero() && t.Before(d.Min) {
-		return fmt.Errorf("date must not be before %v", d.Min.Format(d.Format))
+		return newError("date.before", Params{"min": d.Min.Format(d.Format)})
 	}
 
 	if !d.Max.IsZero() && t.After(d.Max) {
-		return fmt.Errorf("date must not be after %v", d.Max.Format(d.Format))
+		return newError("date.after", Params{"max": d.Max.Format(d.Format)})
 	}
 
 	return nil
@@ -170,22 +233,22 @@ type Required struct{}
 
 func (r Required) Validate(value interface{}) error {
 	if value == nil {
-		return fmt.Errorf("value is required")
+		return newError("required", nil)
 	}
 
 	v := reflect.ValueOf(value)
 	switch v.Kind() {
 	case reflect.String:
 		if v.String() == "" {
-			return fmt.Errorf("value is required")
+			return newError("required", nil)
 		}
 	case reflect.Slice, reflect.Map:
 		if v.Len() == 0 {
-			return fmt.Errorf("value is required")
+			return newError("required", nil)
 		}
 	case reflect.Ptr:
 		if v.IsNil() {
-			return fmt.Errorf("value is required")
+			return newError("required", nil)
 		}
 	}
 	return nil
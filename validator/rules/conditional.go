@@ -1,8 +1,8 @@
 package rules
 
 import (
-	"fmt"
 	"reflect"
+	"regexp"
 )
 
 type When struct {
@@ -12,6 +12,10 @@ type When struct {
 	parent    interface{}
 }
 
+// SetParent stores the parent used by Validate.
+//
+// Deprecated: SetParent mutates the rule and is not safe when the rule is
+// shared between goroutines. Use ValidateContext instead.
 func (w *When) SetParent(parent interface{}) {
 	w.parent = parent
 	if w.Then != nil {
@@ -27,23 +31,40 @@ func (w *When) SetParent(parent interface{}) {
 }
 
 func (w When) Validate(value interface{}) error {
-	if w.Condition(w.parent) {
+	return w.ValidateContext(ValidationContext{Parent: w.parent}, value)
+}
+
+// ValidateContext evaluates Condition against ctx.Parent.
+func (w When) ValidateContext(ctx ValidationContext, value interface{}) error {
+	if w.Condition(ctx.Parent) {
 		if w.Then != nil {
-			return w.Then.Validate(value)
+			return Apply(ctx, w.Then, value)
 		}
 	} else if w.Else != nil {
-		return w.Else.Validate(value)
+		return Apply(ctx, w.Else, value)
 	}
 	return nil
 }
 
+// If applies Then when the condition on Field holds and Else otherwise. By
+// default Field must be a boolean; with Op set, Field is compared with Value
+// instead, e.g. Op: OpEq, Value: "shipped" or Op: OpGte, Value: 18. A string
+// Value is parsed by the type of Field, so Value: "18" works as well; a Value
+// that cannot be compared with Field is an error. Field may be a dotted path
+// into nested structs, pointers and maps.
 type If struct {
 	Field  string
+	Op     string
+	Value  interface{}
 	Then   Rule
 	Else   Rule
 	parent interface{}
 }
 
+// SetParent stores the parent used by Validate.
+//
+// Deprecated: SetParent mutates the rule and is not safe when the rule is
+// shared between goroutines. Use ValidateContext instead.
 func (i *If) SetParent(parent interface{}) {
 	i.parent = parent
 	if i.Then != nil {
@@ -59,45 +80,41 @@ func (i *If) SetParent(parent interface{}) {
 }
 
 func (i If) Validate(value interface{}) error {
-	if i.parent == nil {
-		return fmt.Errorf("parent not set")
-	}
-
-	v := reflect.ValueOf(i.parent)
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-	}
-
-	if v.Kind() != reflect.Struct {
-		return fmt.Errorf("parent must be a struct")
-	}
-
-	field := v.FieldByName(i.Field)
-	if !field.IsValid() {
-		return fmt.Errorf("field %s not found", i.Field)
-	}
+	return i.ValidateContext(ValidationContext{Parent: i.parent}, value)
+}
 
-	if field.Kind() != reflect.Bool {
-		return fmt.Errorf("field %s is not a boolean", i.Field)
+// ValidateContext reads Field from ctx.Parent.
+func (i If) ValidateContext(ctx ValidationContext, value interface{}) error {
+	holds, err := evalCondition("if", ctx.Parent, i.Field, i.Op, i.Value)
+	if err != nil {
+		return err
 	}
 
-	if field.Bool() {
+	if holds {
 		if i.Then != nil {
-			return i.Then.Validate(value)
+			return Apply(ctx, i.Then, value)
 		}
 	} else if i.Else != nil {
-		return i.Else.Validate(value)
+		return Apply(ctx, i.Else, value)
 	}
 	return nil
 }
 
+// Unless applies Then when the condition on Field does not hold and Else
+// otherwise. The condition is written as for If.
 type Unless struct {
 	Field  string
+	Op     string
+	Value  interface{}
 	Then   Rule
 	Else   Rule
 	parent interface{}
 }
 
+// SetParent stores the parent used by Validate.
+//
+// Deprecated: SetParent mutates the rule and is not safe when the rule is
+// shared between goroutines. Use ValidateContext instead.
 func (u *Unless) SetParent(parent interface{}) {
 	u.parent = parent
 	if u.Then != nil {
@@ -113,36 +130,138 @@ func (u *Unless) SetParent(parent interface{}) {
 }
 
 func (u Unless) Validate(value interface{}) error {
-	if u.parent == nil {
-		return fmt.Errorf("parent not set")
+	return u.ValidateContext(ValidationContext{Parent: u.parent}, value)
+}
+
+// ValidateContext reads Field from ctx.Parent.
+func (u Unless) ValidateContext(ctx ValidationContext, value interface{}) error {
+	holds, err := evalCondition("unless", ctx.Parent, u.Field, u.Op, u.Value)
+	if err != nil {
+		return err
+	}
+
+	if !holds {
+		if u.Then != nil {
+			return Apply(ctx, u.Then, value)
+		}
+	} else if u.Else != nil {
+		return Apply(ctx, u.Else, value)
+	}
+	return nil
+}
+
+// Operators of If and Unless besides the comparisons OpEq to OpLte.
+const (
+	// OpIn holds when Field equals one of the elements of Value, a slice or
+	// array.
+	OpIn = "in"
+	// OpNonZero holds when Field is present in the sense of IsEmpty. It
+	// takes no Value.
+	OpNonZero = "nonzero"
+	// OpMatch holds when Field is a string matching Value, a
+	// *regexp.Regexp compiled once when the rule is built.
+	OpMatch = "match"
+)
+
+// evalCondition evaluates the condition of an If or Unless rule. rule
+// prefixes the message keys of the errors.
+func evalCondition(rule string, parent interface{}, field, op string, operand interface{}) (bool, error) {
+	if parent == nil {
+		return false, newError(rule+".no_parent", nil)
 	}
 
-	v := reflect.ValueOf(u.parent)
+	v := reflect.ValueOf(parent)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
-
-	if v.Kind() != reflect.Struct {
-		return fmt.Errorf("parent must be a struct")
+	if v.Kind() != reflect.Struct && v.Kind() != reflect.Map {
+		return false, newError(rule+".parent_type", nil)
 	}
 
-	field := v.FieldByName(u.Field)
-	if !field.IsValid() {
-		return fmt.Errorf("field %s not found", u.Field)
+	f, ok := lookupPath(v, field)
+	if !ok {
+		return false, newError(rule+".field_missing", Params{"other": field})
 	}
 
-	if field.Kind() != reflect.Bool {
-		return fmt.Errorf("field %s is not a boolean", u.Field)
+	switch op {
+	case "":
+		if f = indirect(f); !f.IsValid() || f.Kind() != reflect.Bool {
+			return false, newError(rule+".field_type", Params{"other": field})
+		}
+		return f.Bool(), nil
+	case OpNonZero:
+		return !isEmptyValue(f), nil
+	case OpIn:
+		set := reflect.ValueOf(operand)
+		if set.Kind() != reflect.Slice && set.Kind() != reflect.Array {
+			return false, newError(rule+".operand", Params{"op": op})
+		}
+		for j := 0; j < set.Len(); j++ {
+			if order, ok := compareValues(f, set.Index(j), true); ok && order == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	case OpMatch:
+		re, ok := operand.(*regexp.Regexp)
+		if !ok || re == nil {
+			return false, newError(rule+".operand", Params{"op": op})
+		}
+		f = indirect(f)
+		return f.IsValid() && f.Kind() == reflect.String && re.MatchString(f.String()), nil
+	case OpEq, OpNe, OpGt, OpGte, OpLt, OpLte:
+		if !indirect(f).IsValid() && op != OpEq && op != OpNe {
+			return false, nil
+		}
+		order, ok := compareOperand(f, op, operand)
+		if !ok {
+			return false, newError(rule+".operand", Params{"op": op})
+		}
+		switch op {
+		case OpEq:
+			return order == 0, nil
+		case OpNe:
+			return order != 0, nil
+		case OpGt:
+			return order > 0, nil
+		case OpGte:
+			return order >= 0, nil
+		case OpLt:
+			return order < 0, nil
+		}
+		return order <= 0, nil
 	}
+	return false, newError(rule+".operator", Params{"op": op})
+}
 
-	if !field.Bool() {
-		if u.Then != nil {
-			return u.Then.Validate(value)
+// compareOperand compares field with the operand of a comparison operator.
+// A string operand is parsed by the type of the field, like the values of
+// required_if. ok is false when the operand does not parse or cannot be
+// compared with the field. A nil pointer or missing map key on the way only
+// equals a nil operand.
+func compareOperand(field reflect.Value, op string, operand interface{}) (order int, ok bool) {
+	field = indirect(field)
+	value := reflect.ValueOf(operand)
+	if s, isString := operand.(string); isString {
+		var err error
+		if value, err = parseParam(field, s); err != nil {
+			return 0, false
 		}
-	} else if u.Else != nil {
-		return u.Else.Validate(value)
 	}
-	return nil
+	if !field.IsValid() || !indirect(value).IsValid() {
+		if op != OpEq && op != OpNe {
+			return 0, false
+		}
+		return compareValues(field, value, true)
+	}
+
+	if order, ok := compareValues(field, value, false); ok {
+		return order, true
+	}
+	if (op == OpEq || op == OpNe) && field.Type() == indirect(value).Type() {
+		return compareValues(field, value, true)
+	}
+	return 0, false
 }
 
 type CrossField struct {
@@ -151,20 +270,29 @@ type CrossField struct {
 	parent     interface{}
 }
 
+// SetParent stores the parent used by Validate.
+//
+// Deprecated: SetParent mutates the rule and is not safe when the rule is
+// shared between goroutines. Use ValidateContext instead.
 func (c *CrossField) SetParent(parent interface{}) {
 	c.parent = parent
 }
 
 func (c CrossField) Validate(value interface{}) error {
+	return c.ValidateContext(ValidationContext{Parent: c.parent}, value)
+}
+
+// ValidateContext passes ctx.Parent to ValidateFn.
+func (c CrossField) ValidateContext(ctx ValidationContext, value interface{}) error {
 	if c.ValidateFn == nil {
-		return fmt.Errorf("validation function not provided")
+		return newError("crossfield.no_func", nil)
 	}
 
-	if c.parent == nil {
-		return fmt.Errorf("parent not set")
+	if ctx.Parent == nil {
+		return newError("crossfield.no_parent", nil)
 	}
 
-	return c.ValidateFn(c.parent, value)
+	return c.ValidateFn(ctx.Parent, value)
 }
 
 type DependentRequired struct {
@@ -174,7 +302,7 @@ type DependentRequired struct {
 
 func (d DependentRequired) Validate(value interface{}) error {
 	if d.Parent == nil {
-		return fmt.Errorf("parent struct not provided")
+		return newError("dependent_required.no_parent", nil)
 	}
 
 	parentVal := reflect.ValueOf(d.Parent)
@@ -183,17 +311,17 @@ func (d DependentRequired) Validate(value interface{}) error {
 	}
 
 	if parentVal.Kind() != reflect.Struct {
-		return fmt.Errorf("parent must be a struct")
+		return newError("dependent_required.parent_type", nil)
 	}
 
 	field := parentVal.FieldByName(d.Field)
 	if !field.IsValid() {
-		return fmt.Errorf("field %s not found", d.Field)
+		return newError("dependent_required.field_missing", Params{"other": d.Field})
 	}
 
 	// Check if the field is zero value
 	if field.IsZero() {
-		return fmt.Errorf("field %s is required", d.Field)
+		return newError("dependent_required", Params{"other": d.Field})
 	}
 
 	return nil
@@ -2,6 +2,7 @@ package rules
 
 import (
 	"reflect"
+	"regexp"
 	"testing"
 )
 
@@ -367,6 +368,90 @@ func TestUnless(t *testing.T) {
 	}
 }
 
+type conditionAccount struct {
+	Plan string
+	Age  int
+}
+
+type conditionOrder struct {
+	Status  string
+	Total   float64
+	Coupon  *string
+	Account *conditionAccount
+	Meta    map[string]interface{}
+}
+
+func TestIfUnless_Operators(t *testing.T) {
+	coupon := "SPRING"
+	order := &conditionOrder{
+		Status:  "shipped",
+		Total:   120.5,
+		Coupon:  &coupon,
+		Account: &conditionAccount{Plan: "pro", Age: 17},
+		Meta:    map[string]interface{}{"channel": "web"},
+	}
+
+	tests := []struct {
+		name    string
+		field   string
+		op      string
+		operand interface{}
+		holds   bool
+		wantErr bool
+	}{
+		{name: "eq string", field: "Status", op: OpEq, operand: "shipped", holds: true},
+		{name: "eq string mismatch", field: "Status", op: OpEq, operand: "pending"},
+		{name: "ne string", field: "Status", op: OpNe, operand: "pending", holds: true},
+		{name: "gt across numeric kinds", field: "Total", op: OpGt, operand: 100, holds: true},
+		{name: "lte float", field: "Total", op: OpLte, operand: 100.0},
+		{name: "gte nested path", field: "Account.Age", op: OpGte, operand: 18},
+		{name: "lt nested path", field: "Account.Age", op: OpLt, operand: 18, holds: true},
+		{name: "eq through pointer", field: "Coupon", op: OpEq, operand: "SPRING", holds: true},
+		{name: "eq map key", field: "Meta.channel", op: OpEq, operand: "web", holds: true},
+		{name: "eq missing map key", field: "Meta.source", op: OpEq, operand: "web"},
+		{name: "string operand parsed as int", field: "Account.Age", op: OpGte, operand: "18"},
+		{name: "string operand equals int", field: "Account.Age", op: OpEq, operand: "17", holds: true},
+		{name: "string operand parsed as float", field: "Total", op: OpGt, operand: "100", holds: true},
+		{name: "unparsable string operand", field: "Account.Age", op: OpEq, operand: "seventeen", wantErr: true},
+		{name: "incomparable operand", field: "Status", op: OpGt, operand: 1, wantErr: true},
+		{name: "incomparable equality operand", field: "Total", op: OpEq, operand: true, wantErr: true},
+		{name: "ordering missing map key", field: "Meta.source", op: OpGt, operand: 1},
+		{name: "in set", field: "Account.Plan", op: OpIn, operand: []string{"pro", "team"}, holds: true},
+		{name: "not in set", field: "Account.Plan", op: OpIn, operand: []string{"free"}},
+		{name: "in requires a slice", field: "Account.Plan", op: OpIn, operand: "pro", wantErr: true},
+		{name: "nonzero", field: "Coupon", op: OpNonZero, holds: true},
+		{name: "nonzero missing map key", field: "Meta.source", op: OpNonZero},
+		{name: "match", field: "Coupon", op: OpMatch, operand: regexp.MustCompile("^[A-Z]+$"), holds: true},
+		{name: "no match", field: "Status", op: OpMatch, operand: regexp.MustCompile("^pend"), holds: false},
+		{name: "match pattern string", field: "Status", op: OpMatch, operand: "^pend", wantErr: true},
+		{name: "unknown operator", field: "Status", op: "like", operand: "s%", wantErr: true},
+		{name: "unknown path", field: "Account.Missing", op: OpEq, operand: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := ValidationContext{Parent: order}
+			ifRule := If{Field: tt.field, Op: tt.op, Value: tt.operand, Then: Required{}}
+			unlessRule := Unless{Field: tt.field, Op: tt.op, Value: tt.operand, Then: Required{}}
+
+			ifErr := ifRule.ValidateContext(ctx, "")
+			unlessErr := unlessRule.ValidateContext(ctx, "")
+			if tt.wantErr {
+				if ifErr == nil || unlessErr == nil {
+					t.Errorf("expected errors, got If: %v, Unless: %v", ifErr, unlessErr)
+				}
+				return
+			}
+			if (ifErr != nil) != tt.holds {
+				t.Errorf("If.ValidateContext() error = %v, condition holds %v", ifErr, tt.holds)
+			}
+			if (unlessErr != nil) == tt.holds {
+				t.Errorf("Unless.ValidateContext() error = %v, condition holds %v", unlessErr, tt.holds)
+			}
+		})
+	}
+}
+
 func TestWhen_SetParent(t *testing.T) {
 	type TestStruct struct {
 		Field    bool
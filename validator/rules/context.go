@@ -0,0 +1,45 @@
+package rules
+
+import "context"
+
+// ValidationContext carries the per-call state a rule may need beyond the
+// value it checks. The validator builds a new context for every field it
+// validates, so rules must not retain it.
+type ValidationContext struct {
+	// Parent is the struct holding the field, usually a pointer to it.
+	Parent interface{}
+	// Root is the value passed to the validator.
+	Root interface{}
+	// Path is the dotted path of the field from Root, e.g. Items[2].Quantity.
+	Path string
+	// Context is the context passed to ValidateCtx, or context.Background().
+	Context context.Context
+}
+
+// ContextRule is implemented by rules that depend on the surrounding object,
+// such as If or CrossField. The validator calls ValidateContext instead of
+// Validate for them, which keeps a single rule instance safe to share between
+// goroutines validating different values.
+type ContextRule interface {
+	Rule
+	ValidateContext(ctx ValidationContext, value interface{}) error
+}
+
+// CtxRule is implemented by rules that perform I/O, such as EmailDNS with
+// CheckDNS. ValidateCtx must stop early and return ctx.Err() once ctx is done.
+type CtxRule interface {
+	Rule
+	ValidateCtx(ctx context.Context, value interface{}) error
+}
+
+// Apply validates value with rule, passing ctx on to context rules and its
+// Context on to CtxRule implementations.
+func Apply(ctx ValidationContext, rule Rule, value interface{}) error {
+	if cr, ok := rule.(ContextRule); ok {
+		return cr.ValidateContext(ctx, value)
+	}
+	if cr, ok := rule.(CtxRule); ok && ctx.Context != nil {
+		return cr.ValidateCtx(ctx.Context, value)
+	}
+	return rule.Validate(value)
+}
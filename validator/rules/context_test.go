@@ -0,0 +1,65 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValidateContext(t *testing.T) {
+	premium := &TestStruct{Field: true}
+	basic := &TestStruct{Field: false}
+
+	tests := []struct {
+		name    string
+		rule    ContextRule
+		parent  interface{}
+		value   interface{}
+		wantErr bool
+	}{
+		{"if true requires", &If{Field: "Field", Then: Required{}}, premium, "", true},
+		{"if false skips", &If{Field: "Field", Then: Required{}}, basic, "", false},
+		{"if nil parent", &If{Field: "Field", Then: Required{}}, nil, "x", true},
+		{"unless false requires", Unless{Field: "Field", Then: Required{}}, basic, "", true},
+		{"unless true skips", Unless{Field: "Field", Then: Required{}}, premium, "", false},
+		{"when condition", When{
+			Condition: func(p interface{}) bool { return p.(*TestStruct).Field },
+			Then:      Required{},
+		}, premium, "", true},
+		{"crossfield", CrossField{ValidateFn: func(p, v interface{}) error {
+			if p.(*TestStruct).Value != v {
+				return fmt.Errorf("values differ")
+			}
+			return nil
+		}}, &TestStruct{Value: "a"}, "b", true},
+		{"nested if", If{Field: "Field", Then: &If{Field: "OtherBool", Then: Required{}}}, &TestStruct{Field: true, OtherBool: true}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.ValidateContext(ValidationContext{Parent: tt.parent}, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateContext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateContext_DoesNotUseStoredParent(t *testing.T) {
+	rule := &If{Field: "Field", Then: Required{}}
+	rule.SetParent(&TestStruct{Field: true})
+
+	if err := rule.ValidateContext(ValidationContext{Parent: &TestStruct{Field: false}}, ""); err != nil {
+		t.Errorf("ValidateContext() error = %v, want the context parent to win", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	ctx := ValidationContext{Parent: &TestStruct{Field: true}}
+
+	if err := Apply(ctx, If{Field: "Field", Then: Required{}}, ""); err == nil {
+		t.Error("Apply() expected error from context rule")
+	}
+	if err := Apply(ctx, Required{}, "x"); err != nil {
+		t.Errorf("Apply() unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sgh370/goov/validator/i18n"
+)
+
+// Error is returned by the rules in this package when a value fails
+// validation. Rule names the failing rule, e.g. "length" or "email", so
+// callers can tell failures apart without parsing the message.
+//
+// Key and Params identify the message for translation: Message is the English
+// rendering of Key with Params from the i18n catalog.
+type Error struct {
+	Rule    string
+	Key     string
+	Params  Params
+	Message string
+	// Path locates the failing element inside the validated value, one entry
+	// per collection level: an int index for slices and arrays, or the map
+	// key. It is empty when the value failed as a whole.
+	Path []interface{}
+	// Err is the failure of a nested rule, set by rules such as Each and Map
+	// that apply other rules to the elements of a collection.
+	Err error
+}
+
+func (e *Error) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	var b strings.Builder
+	for _, key := range e.Path {
+		if s, ok := key.(string); ok {
+			fmt.Fprintf(&b, "[%q]", s)
+		} else {
+			fmt.Fprintf(&b, "[%v]", key)
+		}
+	}
+	return b.String() + ": " + e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Params holds the named parameters of a message, e.g. {"min": 8}.
+type Params = i18n.Params
+
+// NewError returns the failure of rule with the message identified by key,
+// rendered in English from the embedded catalog. Custom rules can use their
+// own keys once a catalog provides them; without an English message, Message
+// is the key itself.
+func NewError(rule, key string, params Params) *Error {
+	msg, ok := i18n.English().Format(key, params)
+	if !ok {
+		msg = key
+	}
+	return &Error{Rule: rule, Key: key, Params: params, Message: msg}
+}
+
+// newError is NewError for the rules of this package, whose message keys
+// start with the rule name, e.g. "password.min_length".
+func newError(key string, params Params) error {
+	rule, _, _ := strings.Cut(key, ".")
+	return NewError(rule, key, params)
+}
+
+// wrapElement reports the failure of a nested rule on the element at key,
+// keeping the path and message of the nested failure.
+func wrapElement(rule string, key interface{}, err error) error {
+	e := &Error{Rule: rule, Path: []interface{}{key}, Message: err.Error(), Err: err}
+	if inner, ok := err.(*Error); ok {
+		e.Path = append(e.Path, inner.Path...)
+		e.Key, e.Params = inner.Key, inner.Params
+		e.Message = inner.Message
+	}
+	return e
+}
+
+// wrapKey reports the failure of a nested rule on the map key itself.
+func wrapKey(rule string, key interface{}, err error) error {
+	e := wrapElement(rule, key, err).(*Error)
+	// Keep the nested error as the parameter so that it can be translated
+	// as well; its Error method renders just its message without a path.
+	var msg interface{} = e.Message
+	if inner, ok := err.(*Error); ok && len(inner.Path) == 0 {
+		msg = inner
+	}
+	invalid := NewError(rule, "invalid_key", Params{"message": msg})
+	e.Key, e.Params, e.Message = invalid.Key, invalid.Params, invalid.Message
+	return e
+}
+
+// SortedKeys returns the keys of a map in a stable order so that the first
+// reported failure does not depend on map iteration order. Map and Keys visit
+// keys in this order, and so does the validator when it dives into a map.
+// Integer keys are ordered numerically, other keys by their printed form.
+func SortedKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		}
+		return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+	})
+	return keys
+}
@@ -0,0 +1,153 @@
+package rules
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/sgh370/goov/validator/i18n"
+)
+
+func TestError_RuleIdentity(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     Rule
+		value    interface{}
+		wantRule string
+	}{
+		{"required", Required{}, "", "required"},
+		{"length", Length{Min: 3}, "ab", "length"},
+		{"range", Range{Min: 1, Max: 10}, 11, "range"},
+		{"email", EmailDNS{}, "invalid", "email"},
+		{"port", Port{}, 80, "port"},
+		{"oneof", OneOf{Values: []interface{}{"a"}}, "b", "oneof"},
+		{"semver", SemVer{}, "1.0", "semver"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			var ruleErr *Error
+			if !errors.As(err, &ruleErr) {
+				t.Fatalf("Validate() error = %v, want *Error", err)
+			}
+			if ruleErr.Rule != tt.wantRule {
+				t.Errorf("Rule = %q, want %q", ruleErr.Rule, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestError_Unwrap(t *testing.T) {
+	err := Each{Rule: Positive{}}.Validate([]int{1, -2})
+
+	var ruleErr *Error
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "each" {
+		t.Fatalf("Validate() error = %v, want each error", err)
+	}
+	if err.Error() != "[1]: value must be positive" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+
+	var inner *Error
+	if !errors.As(ruleErr.Unwrap(), &inner) || inner.Rule != "positive" {
+		t.Errorf("Unwrap() = %v, want positive error", ruleErr.Unwrap())
+	}
+}
+
+func TestError_Path(t *testing.T) {
+	rule := Map{Value: Each{Rule: Positive{}}}
+	err := rule.Validate(map[string][]int{
+		"a": {1, 2},
+		"b": {3, -4},
+	})
+
+	var ruleErr *Error
+	if !errors.As(err, &ruleErr) {
+		t.Fatalf("Validate() error = %v, want *Error", err)
+	}
+	if len(ruleErr.Path) != 2 || ruleErr.Path[0] != "b" || ruleErr.Path[1] != 1 {
+		t.Errorf("Path = %v, want [b 1]", ruleErr.Path)
+	}
+	if ruleErr.Message != "value must be positive" {
+		t.Errorf("Message = %q", ruleErr.Message)
+	}
+	if err.Error() != `["b"][1]: value must be positive` {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestError_KeyPath(t *testing.T) {
+	err := Keys{Rules: []Rule{Length{Min: 2}}}.Validate(map[string]int{"ok": 1, "x": 2})
+	if err == nil || err.Error() != `["x"]: invalid map key: length must be at least 2` {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestError_MessageKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       Rule
+		value      interface{}
+		wantKey    string
+		wantParams Params
+		wantMsg    string
+	}{
+		{"required", Required{}, "", "required", nil, "value is required"},
+		{"length", Length{Min: 3}, "ab", "length.min", Params{"min": 3}, "length must be at least 3"},
+		{"password plural", Password{MinLength: 1}, "", "password.min_length", Params{"min": 1}, "password must be at least 1 character"},
+		{"password", Password{MinLength: 8}, "abc", "password.min_length", Params{"min": 8}, "password must be at least 8 characters"},
+		{"type", UUID{}, 5, "uuid.not_string", Params{"type": "int"}, "expected string, got int"},
+		{"map key", Keys{Rules: []Rule{Length{Min: 2}}}, map[string]int{"a": 1}, "invalid_key", nil, "invalid map key: length must be at least 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ruleErr *Error
+			if !errors.As(tt.rule.Validate(tt.value), &ruleErr) {
+				t.Fatal("Validate() expected *Error")
+			}
+			if ruleErr.Key != tt.wantKey || ruleErr.Message != tt.wantMsg {
+				t.Errorf("Key = %q, Message = %q, want %q, %q", ruleErr.Key, ruleErr.Message, tt.wantKey, tt.wantMsg)
+			}
+			for name, want := range tt.wantParams {
+				if ruleErr.Params[name] != want {
+					t.Errorf("Params[%q] = %v, want %v", name, ruleErr.Params[name], want)
+				}
+			}
+		})
+	}
+}
+
+// TestError_KeysHaveEnglishMessages guards against message keys that are
+// missing from the embedded English catalog.
+func TestError_KeysHaveEnglishMessages(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPattern := regexp.MustCompile(`newError\("([a-z_.]+)"`)
+	found := 0
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range keyPattern.FindAllSubmatch(src, -1) {
+			found++
+			if _, ok := i18n.English().Format(string(m[1]), nil); !ok {
+				t.Errorf("%s: key %q has no English message", file, m[1])
+			}
+		}
+	}
+	if found == 0 {
+		t.Error("no message keys found")
+	}
+}
@@ -0,0 +1,32 @@
+package rules
+
+import (
+	"github.com/sgh370/goov/validator/expr"
+)
+
+// Expr passes when Program, a boolean expression of the expr package,
+// evaluates to true, e.g. for the tag expr=Quantity*UnitPrice == TotalPrice.
+// Identifiers refer to the fields of ctx.Parent, and value to the validated
+// value.
+type Expr struct {
+	Program *expr.Program
+}
+
+// Validate evaluates Program without a parent, so only value is available.
+func (e Expr) Validate(value interface{}) error {
+	return e.ValidateContext(ValidationContext{}, value)
+}
+
+// ValidateContext evaluates Program against ctx.Parent. An expression that
+// cannot be evaluated, e.g. because it divides by zero, fails with the key
+// expr.eval.
+func (e Expr) ValidateContext(ctx ValidationContext, value interface{}) error {
+	ok, err := e.Program.EvalBool(ctx.Parent, value)
+	if err != nil {
+		return newError("expr.eval", Params{"expr": e.Program.String(), "error": err.Error()})
+	}
+	if !ok {
+		return newError("expr", Params{"expr": e.Program.String()})
+	}
+	return nil
+}
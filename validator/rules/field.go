@@ -0,0 +1,227 @@
+package rules
+
+import (
+	"cmp"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Comparison operators of FieldCompare.
+const (
+	OpEq  = "eq"
+	OpNe  = "ne"
+	OpGt  = "gt"
+	OpGte = "gte"
+	OpLt  = "lt"
+	OpLte = "lte"
+)
+
+// FieldCompare compares a value with another field of the validated value,
+// e.g. that ConfirmPassword equals Password or UpdatedAt is after CreatedAt.
+// Strings compare lexically, numbers by value across numeric kinds, and
+// time.Time chronologically; time.Duration compares as a number. Other values
+// only support OpEq and OpNe.
+//
+// Field is a dotted path such as "Billing.Country", through nested structs,
+// pointers and maps with string keys. It is resolved from ctx.Parent, or from
+// ctx.Root when CrossStruct is set. An empty Field compares with ctx.Parent
+// itself, as with VarWithField.
+type FieldCompare struct {
+	Field       string
+	Op          string
+	CrossStruct bool
+}
+
+// Name returns the tag name of the rule, e.g. "gtfield" or "eqcsfield".
+func (f FieldCompare) Name() string {
+	if f.CrossStruct {
+		return f.Op + "csfield"
+	}
+	return f.Op + "field"
+}
+
+// fieldName is the {other} parameter of the messages of the rule; without a
+// Field, the rule compares with the other value of VarWithField.
+func (f FieldCompare) fieldName() string {
+	if f.Field == "" {
+		return "other value"
+	}
+	return f.Field
+}
+
+// Validate has no value to read Field from and always fails; the validator
+// calls ValidateContext instead.
+func (f FieldCompare) Validate(value interface{}) error {
+	return f.ValidateContext(ValidationContext{}, value)
+}
+
+// ValidateContext resolves Field and compares value with it.
+func (f FieldCompare) ValidateContext(ctx ValidationContext, value interface{}) error {
+	from := ctx.Parent
+	if f.CrossStruct {
+		from = ctx.Root
+	}
+	if from == nil {
+		return NewError(f.Name(), "field.no_parent", Params{"other": f.fieldName()})
+	}
+
+	other, ok := lookupPath(reflect.ValueOf(from), f.Field)
+	if !ok {
+		return NewError(f.Name(), "field.missing", Params{"other": f.fieldName()})
+	}
+
+	order, ok := compareValues(reflect.ValueOf(value), other, f.Op == OpEq || f.Op == OpNe)
+	if !ok {
+		return NewError(f.Name(), "field.incomparable", Params{"other": f.fieldName()})
+	}
+
+	var pass bool
+	switch f.Op {
+	case OpEq:
+		pass = order == 0
+	case OpNe:
+		pass = order != 0
+	case OpGt:
+		pass = order > 0
+	case OpGte:
+		pass = order >= 0
+	case OpLt:
+		pass = order < 0
+	case OpLte:
+		pass = order <= 0
+	default:
+		return NewError(f.Name(), "field.operator", Params{"op": f.Op})
+	}
+	if !pass {
+		return NewError(f.Name(), "field."+f.Op, Params{"other": f.fieldName()})
+	}
+	return nil
+}
+
+// lookupPath follows the dotted path from v. A key missing from a map
+// resolves to an invalid value; a missing or unexported struct field or a
+// nil pointer on the way is reported as not found.
+func lookupPath(v reflect.Value, path string) (reflect.Value, bool) {
+	if path == "" {
+		return v, true
+	}
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			sf, ok := v.Type().FieldByName(name)
+			if !ok || !sf.IsExported() {
+				return reflect.Value{}, false
+			}
+			f, err := v.FieldByIndexErr(sf.Index)
+			if err != nil {
+				return reflect.Value{}, false
+			}
+			v = f
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, false
+			}
+			v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if !v.IsValid() {
+				return v, true
+			}
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	return v, true
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// compareValues returns -1, 0 or 1 as a is less than, equal to or greater
+// than b. With equality set, values that have no order compare as 0 when
+// they are deeply equal and 1 otherwise.
+func compareValues(a, b reflect.Value, equality bool) (int, bool) {
+	a, b = indirect(a), indirect(b)
+	if !a.IsValid() || !b.IsValid() {
+		if !equality {
+			return 0, false
+		}
+		if a.IsValid() == b.IsValid() {
+			return 0, true
+		}
+		return 1, true
+	}
+
+	switch {
+	case a.Type() == timeType && b.Type() == timeType:
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time)), true
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String()), true
+	}
+
+	if x, ok := toNumber(a); ok {
+		if y, ok := toNumber(b); ok {
+			return x.compare(y), true
+		}
+	}
+
+	if !equality {
+		return 0, false
+	}
+	if a.Type() == b.Type() && reflect.DeepEqual(a.Interface(), b.Interface()) {
+		return 0, true
+	}
+	return 1, true
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// number holds a numeric value without losing the precision of large
+// integers.
+type number struct {
+	kind byte // 'i', 'u' or 'f'
+	i    int64
+	u    uint64
+	f    float64
+}
+
+func toNumber(v reflect.Value) (number, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{kind: 'i', i: v.Int(), f: float64(v.Int())}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return number{kind: 'u', u: v.Uint(), f: float64(v.Uint())}, true
+	case reflect.Float32, reflect.Float64:
+		return number{kind: 'f', f: v.Float()}, true
+	}
+	return number{}, false
+}
+
+func (n number) compare(m number) int {
+	switch {
+	case n.kind == 'i' && m.kind == 'i':
+		return cmp.Compare(n.i, m.i)
+	case n.kind == 'u' && m.kind == 'u':
+		return cmp.Compare(n.u, m.u)
+	case n.kind == 'i' && m.kind == 'u':
+		if n.i < 0 {
+			return -1
+		}
+		return cmp.Compare(uint64(n.i), m.u)
+	case n.kind == 'u' && m.kind == 'i':
+		return -m.compare(n)
+	}
+	return cmp.Compare(n.f, m.f)
+}
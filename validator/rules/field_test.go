@@ -0,0 +1,109 @@
+package rules
+
+import (
+	"testing"
+	"time"
+)
+
+type fieldAddress struct {
+	Country string
+}
+
+type fieldOrder struct {
+	Password  string
+	MinQty    int
+	MaxQty    uint8
+	Price     float64
+	Timeout   time.Duration
+	CreatedAt time.Time
+	Billing   *fieldAddress
+	Tags      []string
+	Meta      map[string]interface{}
+}
+
+func TestFieldCompare(t *testing.T) {
+	now := time.Now()
+	order := &fieldOrder{
+		Password:  "secret",
+		MinQty:    2,
+		MaxQty:    10,
+		Price:     9.5,
+		Timeout:   time.Second,
+		CreatedAt: now,
+		Billing:   &fieldAddress{Country: "DE"},
+		Tags:      []string{"a"},
+		Meta:      map[string]interface{}{"limit": 3},
+	}
+
+	tests := []struct {
+		name    string
+		rule    FieldCompare
+		parent  interface{}
+		value   interface{}
+		wantErr bool
+	}{
+		{"strings equal", FieldCompare{Field: "Password", Op: OpEq}, order, "secret", false},
+		{"strings differ", FieldCompare{Field: "Password", Op: OpEq}, order, "other", true},
+		{"strings ne", FieldCompare{Field: "Password", Op: OpNe}, order, "other", false},
+		{"strings order", FieldCompare{Field: "Password", Op: OpLt}, order, "apple", false},
+		{"int gt", FieldCompare{Field: "MinQty", Op: OpGt}, order, 3, false},
+		{"int not gt", FieldCompare{Field: "MinQty", Op: OpGt}, order, 2, true},
+		{"int gte", FieldCompare{Field: "MinQty", Op: OpGte}, order, 2, false},
+		{"mixed kinds", FieldCompare{Field: "MaxQty", Op: OpLte}, order, int64(10), false},
+		{"negative and unsigned", FieldCompare{Field: "MaxQty", Op: OpLt}, order, -1, false},
+		{"float", FieldCompare{Field: "Price", Op: OpGt}, order, 10, false},
+		{"duration", FieldCompare{Field: "Timeout", Op: OpGt}, order, 2 * time.Second, false},
+		{"time after", FieldCompare{Field: "CreatedAt", Op: OpGt}, order, now.Add(time.Minute), false},
+		{"time before", FieldCompare{Field: "CreatedAt", Op: OpGt}, order, now.Add(-time.Minute), true},
+		{"pointer value", FieldCompare{Field: "CreatedAt", Op: OpEq}, order, &now, false},
+		{"dotted path", FieldCompare{Field: "Billing.Country", Op: OpEq}, order, "DE", false},
+		{"map key", FieldCompare{Field: "Meta.limit", Op: OpLte}, order, 3, false},
+		{"missing map key", FieldCompare{Field: "Meta.other", Op: OpEq}, order, 3, true},
+		{"slices equal", FieldCompare{Field: "Tags", Op: OpEq}, order, []string{"a"}, false},
+		{"slices unordered", FieldCompare{Field: "Tags", Op: OpGt}, order, []string{"a"}, true},
+		{"incomparable", FieldCompare{Field: "Password", Op: OpGt}, order, 1, true},
+		{"missing field", FieldCompare{Field: "Nope", Op: OpEq}, order, "x", true},
+		{"nil on path", FieldCompare{Field: "Billing.Country", Op: OpEq}, &fieldOrder{}, "", true},
+		{"no parent", FieldCompare{Field: "Password", Op: OpEq}, nil, "secret", true},
+		{"parent itself", FieldCompare{Op: OpEq}, "secret", "secret", false},
+		{"unknown operator", FieldCompare{Field: "Password", Op: "like"}, order, "secret", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.ValidateContext(ValidationContext{Parent: tt.parent}, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateContext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFieldCompare_CrossStruct(t *testing.T) {
+	root := &fieldOrder{Billing: &fieldAddress{Country: "DE"}}
+	ctx := ValidationContext{Parent: &fieldAddress{Country: "FR"}, Root: root}
+
+	rule := FieldCompare{Field: "Billing.Country", Op: OpEq, CrossStruct: true}
+	if err := rule.ValidateContext(ctx, "DE"); err != nil {
+		t.Errorf("ValidateContext() unexpected error = %v", err)
+	}
+
+	err := rule.ValidateContext(ctx, "FR")
+	e, ok := err.(*Error)
+	if !ok || e.Rule != "eqcsfield" || e.Key != "field.eq" || e.Message != "value must equal Billing.Country" {
+		t.Errorf("ValidateContext() error = %#v", err)
+	}
+}
+
+func TestFieldCompare_UnexportedField(t *testing.T) {
+	parent := &struct {
+		Updated time.Time
+		created time.Time
+	}{created: time.Now()}
+
+	err := FieldCompare{Field: "created", Op: OpGt}.ValidateContext(ValidationContext{Parent: parent}, time.Now())
+	e, ok := err.(*Error)
+	if !ok || e.Key != "field.missing" {
+		t.Errorf("ValidateContext() error = %v, want field.missing", err)
+	}
+}
@@ -1,6 +1,10 @@
 package rules
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"testing"
+)
 
 func TestEmailDNS(t *testing.T) {
 	tests := []struct {
@@ -74,6 +78,23 @@ func TestEmailDNS(t *testing.T) {
 	}
 }
 
+func TestEmailDNS_ValidateCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := EmailDNS{CheckDNS: true}.ValidateCtx(ctx, "john@example.com")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ValidateCtx() error = %v, want context.Canceled", err)
+	}
+
+	// The format is still checked before any lookup is attempted.
+	err = EmailDNS{CheckDNS: true}.ValidateCtx(ctx, "invalid")
+	var ruleErr *Error
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "email" {
+		t.Errorf("ValidateCtx() error = %v, want email rule error", err)
+	}
+}
+
 func TestHostname(t *testing.T) {
 	tests := []struct {
 		name    string
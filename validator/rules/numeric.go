@@ -1,8 +1,8 @@
 package rules
 
 import (
-	"fmt"
 	"reflect"
+	"unicode/utf8"
 )
 
 type Range struct {
@@ -22,14 +22,14 @@ func (r Range) Validate(value interface{}) error {
 	case reflect.Float32, reflect.Float64:
 		num = v.Float()
 	default:
-		return fmt.Errorf("value must be numeric")
+		return newError("range.not_numeric", nil)
 	}
 
 	if num < r.Min {
-		return fmt.Errorf("value must be greater than or equal to %v", r.Min)
+		return newError("range.min", Params{"min": r.Min, "max": r.Max})
 	}
 	if r.Max > 0 && num > r.Max {
-		return fmt.Errorf("value must be less than or equal to %v", r.Max)
+		return newError("range.max", Params{"min": r.Min, "max": r.Max})
 	}
 	return nil
 }
@@ -42,23 +42,47 @@ func (p Positive) Validate(value interface{}) error {
 	switch v.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		if v.Int() <= 0 {
-			return fmt.Errorf("value must be positive")
+			return newError("positive", nil)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		if v.Uint() == 0 {
-			return fmt.Errorf("value must be positive")
+			return newError("positive", nil)
 		}
 	case reflect.Float32, reflect.Float64:
 		if v.Float() <= 0 {
-			return fmt.Errorf("value must be positive")
+			return newError("positive", nil)
 		}
 	default:
-		return fmt.Errorf("value must be numeric")
+		return newError("positive.not_numeric", nil)
 	}
 	return nil
 }
 
-// Min is a validation rule that ensures a numeric value is greater than or equal to a minimum value
+// Negative ensures a numeric value is less than zero
+type Negative struct{}
+
+func (n Negative) Validate(value interface{}) error {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() >= 0 {
+			return newError("negative", nil)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return newError("negative", nil)
+	case reflect.Float32, reflect.Float64:
+		if v.Float() >= 0 {
+			return newError("negative", nil)
+		}
+	default:
+		return newError("negative.not_numeric", nil)
+	}
+	return nil
+}
+
+// Min is a validation rule that ensures a numeric value is greater than or equal to a minimum value,
+// that a string has at least Value characters, or that a slice, array or map has at least Value elements
 type Min struct {
 	Value float64
 }
@@ -73,18 +97,67 @@ func (m Min) Validate(value interface{}) error {
 	switch v.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		if float64(v.Int()) < m.Value {
-			return fmt.Errorf("value must be greater than or equal to %v", m.Value)
+			return newError("min", Params{"min": m.Value})
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		if float64(v.Uint()) < m.Value {
-			return fmt.Errorf("value must be greater than or equal to %v", m.Value)
+			return newError("min", Params{"min": m.Value})
 		}
 	case reflect.Float32, reflect.Float64:
 		if v.Float() < m.Value {
-			return fmt.Errorf("value must be greater than or equal to %v", m.Value)
+			return newError("min", Params{"min": m.Value})
+		}
+	case reflect.String:
+		if float64(utf8.RuneCountInString(v.String())) < m.Value {
+			return newError("min.length", Params{"min": m.Value})
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if float64(v.Len()) < m.Value {
+			return newError("min.count", Params{"min": m.Value})
+		}
+	default:
+		return newError("min.not_numeric", nil)
+	}
+
+	return nil
+}
+
+// Max is a validation rule that ensures a numeric value is less than or equal to a maximum value,
+// that a string has at most Value characters, or that a slice, array or map has at most Value elements
+type Max struct {
+	Value float64
+}
+
+// Validate implements Rule
+func (m Max) Validate(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if float64(v.Int()) > m.Value {
+			return newError("max", Params{"max": m.Value})
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if float64(v.Uint()) > m.Value {
+			return newError("max", Params{"max": m.Value})
+		}
+	case reflect.Float32, reflect.Float64:
+		if v.Float() > m.Value {
+			return newError("max", Params{"max": m.Value})
+		}
+	case reflect.String:
+		if float64(utf8.RuneCountInString(v.String())) > m.Value {
+			return newError("max.length", Params{"max": m.Value})
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if float64(v.Len()) > m.Value {
+			return newError("max.count", Params{"max": m.Value})
 		}
 	default:
-		return fmt.Errorf("value must be a number")
+		return newError("max.not_numeric", nil)
 	}
 
 	return nil
@@ -63,6 +63,55 @@ func TestPositive(t *testing.T) {
 	}
 }
 
+func TestNegative(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr bool
+	}{
+		{
+			name:    "negative int",
+			value:   -42,
+			wantErr: false,
+		},
+		{
+			name:    "zero int",
+			value:   0,
+			wantErr: true,
+		},
+		{
+			name:    "positive int",
+			value:   42,
+			wantErr: true,
+		},
+		{
+			name:    "negative float",
+			value:   -0.5,
+			wantErr: false,
+		},
+		{
+			name:    "uint",
+			value:   uint(1),
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric value",
+			value:   "-42",
+			wantErr: true,
+		},
+	}
+
+	rule := Negative{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Negative.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestRange(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -182,7 +231,7 @@ func TestMin_Validate(t *testing.T) {
 		{
 			name:    "non-numeric value",
 			rule:    Min{Value: 10},
-			value:   "not a number",
+			value:   true,
 			wantErr: true,
 		},
 		{
@@ -202,3 +251,86 @@ func TestMin_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestMax_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Max
+		value   interface{}
+		wantErr bool
+	}{
+		{
+			name:    "valid int",
+			rule:    Max{Value: 10},
+			value:   5,
+			wantErr: false,
+		},
+		{
+			name:    "invalid int",
+			rule:    Max{Value: 10},
+			value:   15,
+			wantErr: true,
+		},
+		{
+			name:    "valid float",
+			rule:    Max{Value: 10.5},
+			value:   10.5,
+			wantErr: false,
+		},
+		{
+			name:    "invalid uint",
+			rule:    Max{Value: 10},
+			value:   uint(11),
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric value",
+			rule:    Max{Value: 10},
+			value:   true,
+			wantErr: true,
+		},
+		{
+			name:    "nil value",
+			rule:    Max{Value: 10},
+			value:   nil,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Max.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMinMax_Collections(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		value   interface{}
+		wantErr bool
+	}{
+		{"min slice", Min{Value: 1}, []string{"a"}, false},
+		{"min empty slice", Min{Value: 1}, []string{}, true},
+		{"min map", Min{Value: 2}, map[string]int{"a": 1}, true},
+		{"max array", Max{Value: 2}, [3]int{}, true},
+		{"max slice", Max{Value: 2}, []int{1, 2}, false},
+		{"min string", Min{Value: 3}, "go", true},
+		{"max string", Max{Value: 3}, "abc", false},
+		{"max string counts characters", Max{Value: 3}, "äöü", false},
+		{"max long string", Max{Value: 3}, "abcd", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
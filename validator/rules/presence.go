@@ -0,0 +1,201 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Conditions of Presence.
+const (
+	// WhenIf applies when every field equals its value.
+	WhenIf = "if"
+	// WhenUnless applies unless every field equals its value.
+	WhenUnless = "unless"
+	// WhenWith applies when any of the fields is present.
+	WhenWith = "with"
+	// WhenWithAll applies when all of the fields are present.
+	WhenWithAll = "with_all"
+	// WhenWithout applies when any of the fields is missing.
+	WhenWithout = "without"
+	// WhenWithoutAll applies when all of the fields are missing.
+	WhenWithoutAll = "without_all"
+)
+
+// Presence requires a value, or with Exclude requires it to be empty, when
+// the condition When holds for other fields of the parent, e.g. the
+// required_if=ContactMethod phone tag is
+//
+//	Presence{When: WhenIf, Fields: []string{"ContactMethod"}, Values: []string{"phone"}}
+//
+// A value is present when it is neither nil, the zero value of its type, nor
+// an empty slice or map. Fields are dotted paths resolved from ctx.Parent as
+// in FieldCompare. For WhenIf and WhenUnless, Values holds the value of each
+// field, parsed according to the type of the field, so any comparable field
+// type works.
+//
+// The validator skips the other rules of a field once a Presence rule passed
+// and the value is empty, so that a conditionally required field is optional
+// otherwise.
+type Presence struct {
+	Exclude bool
+	When    string
+	Fields  []string
+	Values  []string
+}
+
+// Name returns the tag name of the rule, e.g. "required_if" or
+// "excluded_without_all".
+func (p Presence) Name() string {
+	if p.Exclude {
+		return "excluded_" + p.When
+	}
+	return "required_" + p.When
+}
+
+// Validate has no parent to read the fields from and always fails; the
+// validator calls ValidateContext instead.
+func (p Presence) Validate(value interface{}) error {
+	return p.ValidateContext(ValidationContext{}, value)
+}
+
+// ValidateContext evaluates the condition against ctx.Parent.
+func (p Presence) ValidateContext(ctx ValidationContext, value interface{}) error {
+	if ctx.Parent == nil {
+		return NewError(p.Name(), "field.no_parent", Params{"other": strings.Join(p.Fields, ", ")})
+	}
+
+	applies, err := p.applies(reflect.ValueOf(ctx.Parent))
+	if err != nil || !applies {
+		return err
+	}
+	if IsEmpty(value) == p.Exclude {
+		return nil
+	}
+	return NewError(p.Name(), p.Name(), p.params())
+}
+
+func (p Presence) applies(parent reflect.Value) (bool, error) {
+	if (p.When == WhenIf || p.When == WhenUnless) && len(p.Values) != len(p.Fields) {
+		return false, NewError(p.Name(), "presence.values", nil)
+	}
+
+	matched := 0
+	for i, name := range p.Fields {
+		field, ok := lookupPath(parent, name)
+		if !ok {
+			return false, NewError(p.Name(), "field.missing", Params{"other": name})
+		}
+
+		var match bool
+		switch p.When {
+		case WhenIf, WhenUnless:
+			match = equalsParam(field, p.Values[i])
+		case WhenWith, WhenWithAll:
+			match = !isEmptyValue(field)
+		case WhenWithout, WhenWithoutAll:
+			match = isEmptyValue(field)
+		default:
+			return false, NewError(p.Name(), "presence.condition", Params{"condition": p.When})
+		}
+		if match {
+			matched++
+		}
+	}
+
+	switch p.When {
+	case WhenIf, WhenWithAll, WhenWithoutAll:
+		return matched == len(p.Fields), nil
+	case WhenUnless:
+		return matched < len(p.Fields), nil
+	}
+	return matched > 0, nil
+}
+
+func (p Presence) params() Params {
+	params := Params{
+		"fields": strings.Join(p.Fields, ", "),
+		"n":      len(p.Fields),
+	}
+	if p.When == WhenIf || p.When == WhenUnless {
+		conds := make([]string, len(p.Fields))
+		for i, name := range p.Fields {
+			conds[i] = name + " is " + p.Values[i]
+		}
+		params["condition"] = strings.Join(conds, " and ")
+	}
+	return params
+}
+
+// IsEmpty reports whether value is missing in the sense of Presence: nil, a
+// nil pointer, a pointer to or the zero value of its type, or an empty slice
+// or map.
+func IsEmpty(value interface{}) bool {
+	return isEmptyValue(reflect.ValueOf(value))
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	v = indirect(v)
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// equalsParam reports whether field equals param parsed as a value of the
+// type of field. Nil pointers and missing map keys never match.
+func equalsParam(field reflect.Value, param string) bool {
+	field = indirect(field)
+	if !field.IsValid() {
+		return false
+	}
+
+	value, err := parseParam(field, param)
+	if err != nil {
+		return false
+	}
+	if value.Kind() == reflect.String && field.Kind() != reflect.String {
+		return fmt.Sprint(field.Interface()) == param
+	}
+	order, ok := compareValues(field, value, true)
+	return ok && order == 0
+}
+
+// parseParam parses param as a value of the type of field: a duration,
+// boolean or number. Other types, and an invalid field, take param as a
+// string.
+func parseParam(field reflect.Value, param string) (reflect.Value, error) {
+	switch {
+	case !field.IsValid() || field.Kind() == reflect.String:
+		return reflect.ValueOf(param), nil
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(param)
+		return reflect.ValueOf(d), err
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(param)
+		return reflect.ValueOf(b), err
+	}
+
+	n, ok := toNumber(field)
+	if !ok {
+		return reflect.ValueOf(param), nil
+	}
+	switch n.kind {
+	case 'i':
+		i, err := strconv.ParseInt(param, 10, 64)
+		return reflect.ValueOf(i), err
+	case 'u':
+		u, err := strconv.ParseUint(param, 10, 64)
+		return reflect.ValueOf(u), err
+	}
+	f, err := strconv.ParseFloat(param, 64)
+	return reflect.ValueOf(f), err
+}
@@ -0,0 +1,117 @@
+package rules
+
+import (
+	"testing"
+	"time"
+)
+
+type presenceForm struct {
+	ContactMethod string
+	IsCompany     bool
+	Seats         int
+	Ratio         float64
+	Timeout       time.Duration
+	Email         string
+	Phone         *string
+	Tags          []string
+	Address       *fieldAddress
+}
+
+func TestPresence(t *testing.T) {
+	phone := "+4912345"
+	form := &presenceForm{
+		ContactMethod: "phone",
+		IsCompany:     true,
+		Seats:         3,
+		Ratio:         0.5,
+		Timeout:       time.Minute,
+		Phone:         &phone,
+		Tags:          []string{},
+		Address:       &fieldAddress{Country: "DE"},
+	}
+
+	tests := []struct {
+		name    string
+		rule    Presence
+		value   interface{}
+		wantErr bool
+	}{
+		{"if string matches", Presence{When: WhenIf, Fields: []string{"ContactMethod"}, Values: []string{"phone"}}, "", true},
+		{"if string differs", Presence{When: WhenIf, Fields: []string{"ContactMethod"}, Values: []string{"email"}}, "", false},
+		{"if satisfied", Presence{When: WhenIf, Fields: []string{"ContactMethod"}, Values: []string{"phone"}}, "x", false},
+		{"if bool", Presence{When: WhenIf, Fields: []string{"IsCompany"}, Values: []string{"true"}}, "", true},
+		{"if int", Presence{When: WhenIf, Fields: []string{"Seats"}, Values: []string{"3"}}, 0, true},
+		{"if float", Presence{When: WhenIf, Fields: []string{"Ratio"}, Values: []string{"0.5"}}, nil, true},
+		{"if duration", Presence{When: WhenIf, Fields: []string{"Timeout"}, Values: []string{"1m"}}, "", true},
+		{"if pointer", Presence{When: WhenIf, Fields: []string{"Phone"}, Values: []string{"+4912345"}}, "", true},
+		{"if dotted path", Presence{When: WhenIf, Fields: []string{"Address.Country"}, Values: []string{"DE"}}, "", true},
+		{"if needs every pair", Presence{When: WhenIf, Fields: []string{"ContactMethod", "Seats"}, Values: []string{"phone", "4"}}, "", false},
+		{"unless matches", Presence{When: WhenUnless, Fields: []string{"IsCompany"}, Values: []string{"true"}}, "", false},
+		{"unless differs", Presence{When: WhenUnless, Fields: []string{"IsCompany"}, Values: []string{"false"}}, "", true},
+		{"with present", Presence{When: WhenWith, Fields: []string{"Email", "Phone"}}, "", true},
+		{"with missing", Presence{When: WhenWith, Fields: []string{"Email", "Tags"}}, "", false},
+		{"with all", Presence{When: WhenWithAll, Fields: []string{"Email", "Phone"}}, "", false},
+		{"without", Presence{When: WhenWithout, Fields: []string{"Email", "Phone"}}, "", true},
+		{"without all", Presence{When: WhenWithoutAll, Fields: []string{"Email", "Phone"}}, "", false},
+		{"without all missing", Presence{When: WhenWithoutAll, Fields: []string{"Email", "Tags"}}, "", true},
+		{"excluded if", Presence{Exclude: true, When: WhenIf, Fields: []string{"IsCompany"}, Values: []string{"true"}}, "x", true},
+		{"excluded if empty", Presence{Exclude: true, When: WhenIf, Fields: []string{"IsCompany"}, Values: []string{"true"}}, "", false},
+		{"excluded unless", Presence{Exclude: true, When: WhenUnless, Fields: []string{"IsCompany"}, Values: []string{"true"}}, "x", false},
+		{"missing field", Presence{When: WhenWith, Fields: []string{"Nope"}}, "", true},
+		{"missing values", Presence{When: WhenIf, Fields: []string{"Seats"}}, "", true},
+		{"unknown condition", Presence{When: "maybe", Fields: []string{"Seats"}}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.ValidateContext(ValidationContext{Parent: form}, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateContext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := (Presence{When: WhenWith, Fields: []string{"Email"}}).Validate(""); err == nil {
+		t.Error("Validate() expected an error without a parent")
+	}
+}
+
+func TestPresence_Messages(t *testing.T) {
+	form := &presenceForm{ContactMethod: "phone", Email: "a@b.co"}
+
+	tests := []struct {
+		rule Presence
+		want string
+	}{
+		{Presence{When: WhenIf, Fields: []string{"ContactMethod"}, Values: []string{"phone"}}, "value is required when ContactMethod is phone"},
+		{Presence{When: WhenWith, Fields: []string{"Email"}}, "value is required when Email is set"},
+		{Presence{When: WhenWithoutAll, Fields: []string{"Phone", "Tags"}}, "value is required when Phone, Tags are all missing"},
+		{Presence{Exclude: true, When: WhenWith, Fields: []string{"Email", "Phone"}}, "value must be empty when any of Email, Phone is set"},
+	}
+
+	for _, tt := range tests {
+		value := interface{}("")
+		if tt.rule.Exclude {
+			value = "x"
+		}
+		err := tt.rule.ValidateContext(ValidationContext{Parent: form}, value)
+		e, ok := err.(*Error)
+		if !ok || e.Rule != tt.rule.Name() || e.Key != tt.rule.Name() || e.Message != tt.want {
+			t.Errorf("%s: error = %#v, want %q", tt.rule.Name(), err, tt.want)
+		}
+	}
+}
+
+func TestPresence_UnexportedField(t *testing.T) {
+	parent := &struct {
+		Name string
+		hid  struct{ X string }
+	}{}
+
+	rule := Presence{When: WhenIf, Fields: []string{"hid"}, Values: []string{"x"}}
+	err := rule.ValidateContext(ValidationContext{Parent: parent}, "")
+	e, ok := err.(*Error)
+	if !ok || e.Key != "field.missing" {
+		t.Errorf("ValidateContext() error = %v, want field.missing", err)
+	}
+}
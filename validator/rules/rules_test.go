@@ -2,6 +2,7 @@ package rules
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 )
@@ -9,7 +10,7 @@ import (
 func TestLength(t *testing.T) {
 	tests := []struct {
 		name    string
-		rule    Length
+		rule    Rule
 		value   interface{}
 		wantErr bool
 	}{
@@ -19,12 +20,18 @@ func TestLength(t *testing.T) {
 		{"too short", Length{Min: 2, Max: 3}, []int{1}, true},
 		{"too long", Length{Min: 1, Max: 2}, []int{1, 2, 3}, true},
 		{"invalid type", Length{Min: 1, Max: 3}, 123, true},
+		{"string counts characters", Length{Min: 3, Max: 5}, "äöü", false},
+		{"exact", ExactLength{Value: 3}, "äöü", false},
+		{"exact too long", ExactLength{Value: 3}, []int{1, 2, 3, 4}, true},
+		{"exact zero", ExactLength{Value: 0}, "", false},
+		{"exact zero non-empty", ExactLength{Value: 0}, "a", true},
+		{"exact invalid type", ExactLength{Value: 3}, 123, true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if err := tt.rule.Validate(tt.value); (err != nil) != tt.wantErr {
-				t.Errorf("Length.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -128,6 +135,8 @@ func TestOneOf(t *testing.T) {
 		{"valid string", OneOf{Values: []interface{}{"a", "b", "c"}}, "b", false},
 		{"valid int", OneOf{Values: []interface{}{1, 2, 3}}, 2, false},
 		{"invalid value", OneOf{Values: []interface{}{"a", "b", "c"}}, "d", true},
+		{"int against tag strings", OneOf{Values: []interface{}{"1", "2"}}, 2, false},
+		{"int not in tag strings", OneOf{Values: []interface{}{"1", "2"}}, 3, true},
 	}
 
 	for _, tt := range tests {
@@ -139,6 +148,28 @@ func TestOneOf(t *testing.T) {
 	}
 }
 
+func TestRegex(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Regex
+		value   interface{}
+		wantErr bool
+	}{
+		{"matching", Regex{Pattern: regexp.MustCompile(`^[a-z]+$`)}, "abc", false},
+		{"not matching", Regex{Pattern: regexp.MustCompile(`^[a-z]+$`)}, "ABC", true},
+		{"no pattern", Regex{}, "abc", true},
+		{"invalid type", Regex{Pattern: regexp.MustCompile(`^[a-z]+$`)}, 123, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("Regex.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestCustom(t *testing.T) {
 	evenNumber := Custom{
 		Fn: func(value interface{}) error {
@@ -233,6 +264,33 @@ func TestPhone(t *testing.T) {
 	}
 }
 
+func TestAlphanumLowercase(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      Rule
+		value     interface{}
+		wantError bool
+	}{
+		{"alphanum", Alphanum{}, "gopher42", false},
+		{"alphanum with dash", Alphanum{}, "go-pher", true},
+		{"alphanum non-ASCII letter", Alphanum{}, "göpher", true},
+		{"alphanum empty", Alphanum{}, "", true},
+		{"alphanum invalid type", Alphanum{}, 42, true},
+		{"lowercase", Lowercase{}, "gopher-42", false},
+		{"lowercase with uppercase", Lowercase{}, "Gopher", true},
+		{"lowercase invalid type", Lowercase{}, 42, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantError {
+				t.Errorf("Validate() error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
 func TestUUID(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,211 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+// ValidateMap validates a dynamic document, such as decoded JSON, against a
+// schema. The schema maps each key to one of:
+//
+//   - a tag string, validated against the value of the key;
+//   - a nested schema, map[string]interface{}, for an object. Its "" key may
+//     hold the tag of the object itself;
+//   - an array schema, []interface{}{elem} or []interface{}{tag, elem}, whose
+//     elem schema applies to every element and whose tag applies to the
+//     array itself.
+//
+// For example:
+//
+//	schema := map[string]interface{}{
+//		"event": "required,oneof=created deleted",
+//		"customer": map[string]interface{}{
+//			"":      "required",
+//			"email": "required,email",
+//		},
+//		"items": []interface{}{"required", map[string]interface{}{
+//			"sku": "required",
+//		}},
+//	}
+//
+// Missing keys are validated as nil, so only rules such as required reject
+// them, and keys without a schema are ignored. Errors are reported like
+// struct errors, with keys as field names, e.g. items[0].sku. Cross-field
+// rules receive the enclosing object as their parent.
+func (v *Validator) ValidateMap(data map[string]interface{}, schema map[string]interface{}) error {
+	return v.ValidateMapCtx(context.Background(), data, schema)
+}
+
+// ValidateMapCtx is like ValidateMap but bounds the walk by ctx, as
+// ValidateCtx does.
+func (v *Validator) ValidateMapCtx(ctx context.Context, data map[string]interface{}, schema map[string]interface{}) error {
+	root, err := v.compileSchema(schema, "")
+	if err != nil {
+		return err
+	}
+
+	w := v.newWalker(ctx, data, false)
+	defer w.release()
+	if err := w.validateObject(data, root); err != nil && err != errStop {
+		return err
+	}
+	if len(w.errs) > 0 {
+		return w.errs
+	}
+	return nil
+}
+
+// schemaNode is the compiled schema of one value of a document.
+type schemaNode struct {
+	rules []tagRule
+	// keys and fields describe an object, in the order keys are validated.
+	keys   []string
+	fields map[string]*schemaNode
+	// elem describes the elements of an array.
+	elem *schemaNode
+}
+
+// compileSchema compiles the schema of an object. path locates it in the
+// schema for errors.
+func (v *Validator) compileSchema(schema map[string]interface{}, path string) (*schemaNode, error) {
+	n := &schemaNode{fields: make(map[string]*schemaNode, len(schema))}
+	for key, s := range schema {
+		if key == "" {
+			tag, ok := s.(string)
+			if !ok {
+				return nil, fmt.Errorf("schema %s: the \"\" key must hold a tag, got %T", schemaPath(path, ""), s)
+			}
+			rules, err := v.schemaRules(tag, path)
+			if err != nil {
+				return nil, err
+			}
+			n.rules = rules
+			continue
+		}
+
+		field, err := v.compileSchemaValue(s, schemaPath(path, key))
+		if err != nil {
+			return nil, err
+		}
+		n.keys = append(n.keys, key)
+		n.fields[key] = field
+	}
+	sort.Strings(n.keys)
+	return n, nil
+}
+
+func (v *Validator) compileSchemaValue(s interface{}, path string) (*schemaNode, error) {
+	switch s := s.(type) {
+	case string:
+		rules, err := v.schemaRules(s, path)
+		if err != nil {
+			return nil, err
+		}
+		return &schemaNode{rules: rules}, nil
+	case map[string]interface{}:
+		return v.compileSchema(s, path)
+	case []interface{}:
+		var tag interface{} = ""
+		switch len(s) {
+		case 1:
+		case 2:
+			tag = s[0]
+		default:
+			return nil, fmt.Errorf("schema %s: an array schema holds an element schema and an optional tag, got %d entries", path, len(s))
+		}
+		tagStr, ok := tag.(string)
+		if !ok {
+			return nil, fmt.Errorf("schema %s: the tag of an array must be a string, got %T", path, tag)
+		}
+		rules, err := v.schemaRules(tagStr, path)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := v.compileSchemaValue(s[len(s)-1], path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		return &schemaNode{rules: rules, elem: elem}, nil
+	}
+	return nil, fmt.Errorf("schema %s: unsupported schema %T", path, s)
+}
+
+// schemaRules resolves a tag of the schema through the cache of Var tags,
+// which also checks its expr rules.
+func (v *Validator) schemaRules(tag, path string) ([]tagRule, error) {
+	plan := v.varPlanFor(tag)
+	if plan.err != nil {
+		return nil, fmt.Errorf("schema %s: %w", path, plan.err)
+	}
+	return plan.rules, nil
+}
+
+func schemaPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// validateObject validates the keys of obj against the fields of n.
+func (w *walker) validateObject(obj map[string]interface{}, n *schemaNode) error {
+	for _, key := range n.keys {
+		if err := w.aborted(); err != nil {
+			return err
+		}
+
+		label := w.label
+		w.label = key
+		w.push(PathSegment{Field: key})
+		err := w.validateDocument(obj[key], n.fields[key], obj)
+		w.pop()
+		w.label = label
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDocument validates a value of a document with the rules of n, then
+// its keys or elements when n describes an object or an array.
+func (w *walker) validateDocument(value interface{}, n *schemaNode, parent map[string]interface{}) error {
+	val := reflect.ValueOf(&value).Elem()
+	failed := len(w.errs)
+	if err := w.validateValue(val, n.rules, parent); err != nil {
+		return err
+	}
+	if len(w.errs) > failed || value == nil || (len(n.rules) > 0 && omitted(n.rules[0].name, val)) {
+		return nil
+	}
+
+	switch {
+	case n.elem != nil:
+		arr, ok := value.([]interface{})
+		if !ok {
+			return w.report(w.fieldError(tagRule{name: "schema"}, val.Elem(), rules.NewError("schema", "schema.array", nil)))
+		}
+		for i, elem := range arr {
+			if err := w.aborted(); err != nil {
+				return err
+			}
+			w.push(PathSegment{Key: i})
+			err := w.validateDocument(elem, n.elem, parent)
+			w.pop()
+			if err != nil {
+				return err
+			}
+		}
+	case n.fields != nil:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return w.report(w.fieldError(tagRule{name: "schema"}, val.Elem(), rules.NewError("schema", "schema.object", nil)))
+		}
+		return w.validateObject(obj, n)
+	}
+	return nil
+}
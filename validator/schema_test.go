@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+var webhookSchema = map[string]interface{}{
+	"event": "required,oneof=created deleted",
+	"customer": map[string]interface{}{
+		"":      "required",
+		"email": "required,email",
+		"name":  "omitempty,length=2:50",
+	},
+	"items": []interface{}{"required", map[string]interface{}{
+		"sku":      "required",
+		"quantity": "required,min=1",
+	}},
+	"tags":     []interface{}{"length=2:10"},
+	"metadata": map[string]interface{}{"source": "required"},
+}
+
+func decodeDocument(t *testing.T, doc string) map[string]interface{} {
+	t.Helper()
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(doc), &data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestValidateMap(t *testing.T) {
+	tests := []struct {
+		name      string
+		doc       string
+		wantErr   bool
+		namespace string
+		rule      string
+	}{
+		{"valid", `{"event": "created", "customer": {"email": "a@b.co"}, "items": [{"sku": "x", "quantity": 2}], "extra": 1}`, false, "", ""},
+		{"missing key", `{"customer": {"email": "a@b.co"}, "items": []}`, true, "event", "required"},
+		{"missing object", `{"event": "created", "items": [{"sku": "x", "quantity": 1}]}`, true, "customer", "required"},
+		{"nested key", `{"event": "created", "customer": {"email": "nope"}, "items": []}`, true, "customer.email", "email"},
+		{"omitempty", `{"event": "created", "customer": {"email": "a@b.co", "name": ""}, "items": [{"sku": "x", "quantity": 1}]}`, false, "", ""},
+		{"array element", `{"event": "created", "customer": {"email": "a@b.co"}, "items": [{"sku": "x", "quantity": 1}, {"quantity": 1}]}`, true, "items[1].sku", "required"},
+		{"number rule", `{"event": "created", "customer": {"email": "a@b.co"}, "items": [{"sku": "x", "quantity": 0}]}`, true, "items[0].quantity", "min"},
+		{"scalar elements", `{"event": "created", "customer": {"email": "a@b.co"}, "items": [{"sku": "x", "quantity": 1}], "tags": ["ok", "x"]}`, true, "tags[1]", "length"},
+		{"not an object", `{"event": "created", "customer": "a@b.co", "items": []}`, true, "customer", "schema"},
+		{"not an array", `{"event": "created", "customer": {"email": "a@b.co"}, "items": {"sku": "x"}}`, true, "items", "schema"},
+		{"optional object", `{"event": "created", "customer": {"email": "a@b.co"}, "items": [{"sku": "x", "quantity": 1}], "metadata": {}}`, true, "metadata.source", "required"},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateMap(decodeDocument(t, tt.doc), webhookSchema)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var verrs ValidationErrors
+			if !errors.As(err, &verrs) {
+				t.Fatalf("ValidateMap() error = %v, want ValidationErrors", err)
+			}
+			if verrs[0].Namespace != tt.namespace || verrs[0].Rule != tt.rule {
+				t.Errorf("Namespace = %q, Rule = %q, want %q, %q", verrs[0].Namespace, verrs[0].Rule, tt.namespace, tt.rule)
+			}
+		})
+	}
+}
+
+func TestValidateMap_JSONPointer(t *testing.T) {
+	v := New()
+	v.SetPathFormat(PathJSONPointer)
+
+	doc := decodeDocument(t, `{"event": "created", "customer": {"email": "a@b.co"}, "items": [{"sku": "x", "quantity": 0}]}`)
+	var fe *FieldError
+	if err := v.ValidateMap(doc, webhookSchema); !errors.As(err, &fe) {
+		t.Fatalf("ValidateMap() error = %v, want a *FieldError", err)
+	}
+	if fe.Namespace != "/items/0/quantity" || fe.Label != "quantity" {
+		t.Errorf("Namespace = %q, Label = %q", fe.Namespace, fe.Label)
+	}
+}
+
+func TestValidateMap_SchemaErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema map[string]interface{}
+	}{
+		{"unknown rule", map[string]interface{}{"a": map[string]interface{}{"b": "unknown"}}},
+		{"unsupported schema", map[string]interface{}{"a": 1}},
+		{"empty array schema", map[string]interface{}{"a": []interface{}{}}},
+		{"array tag", map[string]interface{}{"a": []interface{}{1, "required"}}},
+		{"object tag", map[string]interface{}{"a": map[string]interface{}{"": 1}}},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateMap(map[string]interface{}{}, tt.schema)
+			if _, ok := err.(ValidationErrors); err == nil || ok {
+				t.Errorf("ValidateMap() error = %v, want a schema error", err)
+			}
+		})
+	}
+}
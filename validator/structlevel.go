@@ -0,0 +1,166 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Validatable is implemented by types that check invariants spanning several
+// fields. The validator calls Validate after the field rules of the type pass.
+// Validate must not validate its receiver with the same validator, or the
+// walk recurses forever.
+type Validatable interface {
+	Validate() error
+}
+
+// ValidatableCtx is the context-aware variant of Validatable. It receives the
+// context of ValidateCtx and takes precedence over Validate.
+type ValidatableCtx interface {
+	ValidateCtx(ctx context.Context) error
+}
+
+// StructRule checks an invariant of a struct type. value is a pointer to the
+// struct being validated.
+type StructRule func(ctx context.Context, value interface{}) error
+
+// FieldViolation is returned by struct-level checks to report a failure on a
+// field of the struct instead of on the struct as a whole. Field is the Go
+// field name, or a dotted path such as "Address.City" for nested structs.
+// Several violations can be returned at once with errors.Join.
+type FieldViolation struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+func (e *FieldViolation) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+var (
+	validatableType    = reflect.TypeOf((*Validatable)(nil)).Elem()
+	validatableCtxType = reflect.TypeOf((*ValidatableCtx)(nil)).Elem()
+)
+
+// RegisterStructRule attaches rule to typ, which may be a struct type or a
+// pointer to one. Struct rules run after the field rules of typ pass, and
+// after its own Validate method, in the order they were registered. They let
+// you add invariants to types you don't own.
+func (v *Validator) RegisterStructRule(typ reflect.Type, rule StructRule) {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	v.structRules[typ] = append(v.structRules[typ], rule)
+	v.resetPlans()
+}
+
+// structRulesFor returns the struct-level checks of typ: its Validate method
+// if it has one, followed by the registered struct rules.
+func (v *Validator) structRulesFor(typ reflect.Type) []StructRule {
+	var hooks []StructRule
+	ptr := reflect.PointerTo(typ)
+	switch {
+	case ptr.Implements(validatableCtxType):
+		hooks = append(hooks, func(ctx context.Context, value interface{}) error {
+			return value.(ValidatableCtx).ValidateCtx(ctx)
+		})
+	case ptr.Implements(validatableType):
+		hooks = append(hooks, func(ctx context.Context, value interface{}) error {
+			return value.(Validatable).Validate()
+		})
+	}
+	return append(hooks, v.structRules[typ]...)
+}
+
+// validateStructLevel runs the struct-level checks of plan on val. Failures
+// are reported at the path of the struct under the rule name "struct", or at
+// the targeted field for a *FieldViolation.
+func (w *walker) validateStructLevel(val reflect.Value, plan *structPlan) error {
+	// Hooks always receive a pointer, so methods with pointer receivers work
+	// on values that are not addressable, such as map elements.
+	if !val.CanAddr() {
+		copied := reflect.New(val.Type()).Elem()
+		copied.Set(val)
+		val = copied
+	}
+	ptr := val.Addr().Interface()
+
+	for _, hook := range plan.hooks {
+		err := hook(w.ctx, ptr)
+		if err == nil {
+			continue
+		}
+		if abort := w.aborted(); abort != nil {
+			return abort
+		}
+		for _, e := range flattenErrors(err) {
+			if err := w.report(w.structError(val, e)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *walker) structError(val reflect.Value, err error) *FieldError {
+	r := tagRule{name: "struct"}
+	var violation *FieldViolation
+	if !errors.As(err, &violation) {
+		fe := w.v.newFieldError(appendPath(nil, w.path...), r, val, err)
+		fe.Label = w.label
+		w.localize(fe)
+		w.customize(fe)
+		return fe
+	}
+
+	// Violations name fields by their Go names; the path uses the reported
+	// names so it matches the errors of field rules.
+	path := appendPath(nil, w.path...)
+	field, label := val, ""
+	for _, name := range strings.Split(violation.Field, ".") {
+		if field.Kind() == reflect.Ptr && !field.IsNil() {
+			field = field.Elem()
+		}
+		sf, ok := reflect.StructField{}, false
+		if field.Kind() == reflect.Struct {
+			sf, ok = field.Type().FieldByName(name)
+		}
+		if !ok {
+			path = appendPath(path, PathSegment{Field: name})
+			field, label = reflect.Value{}, name
+			continue
+		}
+		path = appendPath(path, PathSegment{Field: w.v.fieldName(sf)})
+		label = w.v.fieldLabel(sf)
+		next, lookupErr := field.FieldByIndexErr(sf.Index)
+		if lookupErr != nil {
+			next = reflect.Value{}
+		}
+		field = next
+	}
+	if violation.Rule != "" {
+		r.name = violation.Rule
+	}
+
+	fe := w.v.newFieldError(path, r, field, err)
+	fe.Message = violation.Message
+	fe.Label = label
+	w.customize(fe)
+	return fe
+}
+
+// flattenErrors splits errors combined with errors.Join, or any error with an
+// Unwrap() []error method, into their leaves.
+func flattenErrors(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var leaves []error
+	for _, e := range joined.Unwrap() {
+		leaves = append(leaves, flattenErrors(e)...)
+	}
+	return leaves
+}
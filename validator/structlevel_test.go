@@ -0,0 +1,154 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type HookContact struct {
+	Name        string `validate:"required"`
+	Email       string
+	PhoneNumber string
+}
+
+func (c HookContact) Validate() error {
+	if c.Email == "" && c.PhoneNumber == "" {
+		return errors.New("email or phone number is required")
+	}
+	return nil
+}
+
+type HookLine struct {
+	Quantity   int `validate:"min=1"`
+	UnitPrice  float64
+	TotalPrice float64
+}
+
+type HookInvoice struct {
+	Contact HookContact
+	Lines   map[string]*HookLine
+}
+
+type ctxKey struct{}
+
+type HookTenant struct {
+	ID string
+}
+
+func (t *HookTenant) ValidateCtx(ctx context.Context) error {
+	if ctx.Value(ctxKey{}) != t.ID {
+		return &FieldViolation{Field: "ID", Rule: "tenant", Message: "tenant does not match the request"}
+	}
+	return nil
+}
+
+func (t *HookTenant) Validate() error {
+	return errors.New("Validate must not be called when ValidateCtx exists")
+}
+
+func lineTotal(ctx context.Context, value interface{}) error {
+	line := value.(*HookLine)
+	if line.TotalPrice != float64(line.Quantity)*line.UnitPrice {
+		return &FieldViolation{Field: "TotalPrice", Rule: "total", Message: "must equal quantity times unit price"}
+	}
+	return nil
+}
+
+func TestValidate_Validatable(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     HookContact
+		wantErr   bool
+		namespace string
+		rule      string
+	}{
+		{"email set", HookContact{Name: "Ann", Email: "ann@example.com"}, false, "", ""},
+		{"phone set", HookContact{Name: "Ann", PhoneNumber: "+4912345"}, false, "", ""},
+		{"neither set", HookContact{Name: "Ann"}, true, "", "struct"},
+		{"field rules first", HookContact{}, true, "Name", "required"},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var verrs ValidationErrors
+			if !errors.As(err, &verrs) || len(verrs) != 1 {
+				t.Fatalf("Validate() error = %v, want one field error", err)
+			}
+			if verrs[0].Namespace != tt.namespace || verrs[0].Rule != tt.rule {
+				t.Errorf("Namespace = %q, Rule = %q, want %q, %q", verrs[0].Namespace, verrs[0].Rule, tt.namespace, tt.rule)
+			}
+		})
+	}
+}
+
+func TestRegisterStructRule(t *testing.T) {
+	v := New()
+	v.RegisterStructRule(reflect.TypeOf(HookLine{}), lineTotal)
+
+	invoice := HookInvoice{
+		Contact: HookContact{Name: "Ann"},
+		Lines: map[string]*HookLine{
+			"a": {Quantity: 2, UnitPrice: 5, TotalPrice: 10},
+			"b": {Quantity: 1, UnitPrice: 5, TotalPrice: 4},
+			"c": {Quantity: 0, UnitPrice: 5, TotalPrice: 1},
+		},
+	}
+
+	got := namespaces(v.ValidateAll(invoice))
+	want := []string{"Contact", `Lines["b"].TotalPrice`, `Lines["c"].Quantity`}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ValidateAll() = %v, want %v", got, want)
+	}
+
+	var verrs ValidationErrors
+	err := v.Validate(HookLine{Quantity: 1, UnitPrice: 5, TotalPrice: 4})
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+	}
+	fe := verrs[0]
+	if fe.Rule != "total" || fe.Value != 4.0 || fe.Message != "must equal quantity times unit price" {
+		t.Errorf("FieldError = %+v", fe)
+	}
+}
+
+func TestRegisterStructRule_JoinedViolations(t *testing.T) {
+	v := New()
+	v.RegisterStructRule(reflect.TypeOf(&HookContact{}), func(ctx context.Context, value interface{}) error {
+		return errors.Join(
+			&FieldViolation{Field: "Email", Message: "is blocked"},
+			&FieldViolation{Field: "PhoneNumber", Message: "is blocked"},
+		)
+	})
+
+	got := namespaces(v.ValidateAll(HookContact{Name: "Ann", Email: "x"}))
+	want := []string{"Email", "PhoneNumber"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ValidateAll() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateCtx_ValidatableCtx(t *testing.T) {
+	v := New()
+	ctx := context.WithValue(context.Background(), ctxKey{}, "acme")
+
+	if err := v.ValidateCtx(ctx, HookTenant{ID: "acme"}); err != nil {
+		t.Errorf("ValidateCtx() unexpected error = %v", err)
+	}
+
+	err := v.ValidateCtx(ctx, &HookTenant{ID: "other"})
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) || verrs[0].Namespace != "ID" || verrs[0].Rule != "tenant" {
+		t.Errorf("ValidateCtx() error = %v, want a tenant error on ID", err)
+	}
+}
@@ -0,0 +1,215 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+// TagSyntaxError reports a malformed validate tag. Column is the 1-based byte
+// offset in Tag where the problem was found.
+type TagSyntaxError struct {
+	Tag    string
+	Column int
+	Msg    string
+}
+
+func (e *TagSyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at column %d: %s", e.Column, e.Msg)
+}
+
+// tagTerm is one rule reference in a validate tag, such as "max=10" or the
+// legacy "length:3:20". raw is the term as written, without surrounding
+// whitespace.
+type tagTerm struct {
+	raw      string
+	name     string
+	param    string
+	hasParam bool
+}
+
+// lexTag splits a validate tag into its comma separated entries, each holding
+// one or more alternatives separated by "|".
+//
+// A parameter follows the rule name after "=" (or the legacy ":") and runs to
+// the next unescaped "," or "|". Inside it, a backslash escapes ",", "|", "'"
+// and itself; any other backslash is kept, so regexes need no extra escaping.
+// A parameter may instead be enclosed in single quotes, in which case it is
+// taken literally apart from the escapes \' and \\. Whitespace around names,
+// separators and parameters is ignored.
+func lexTag(tag string) ([][]tagTerm, error) {
+	l := tagLexer{tag: tag}
+	var entries [][]tagTerm
+	var alts []tagTerm
+	for {
+		term, err := l.term()
+		if err != nil {
+			return nil, err
+		}
+		alts = append(alts, term)
+
+		switch l.next() {
+		case '|':
+			continue
+		case ',':
+			entries = append(entries, alts)
+			alts = nil
+		case 0:
+			return append(entries, alts), nil
+		}
+	}
+}
+
+type tagLexer struct {
+	tag string
+	pos int
+}
+
+func (l *tagLexer) errorf(pos int, format string, args ...interface{}) error {
+	return &TagSyntaxError{Tag: l.tag, Column: pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (l *tagLexer) peek() byte {
+	if l.pos < len(l.tag) {
+		return l.tag[l.pos]
+	}
+	return 0
+}
+
+func (l *tagLexer) next() byte {
+	c := l.peek()
+	if c != 0 {
+		l.pos++
+	}
+	return c
+}
+
+func (l *tagLexer) skipSpace() {
+	for l.pos < len(l.tag) && isSpace(l.tag[l.pos]) {
+		l.pos++
+	}
+}
+
+func (l *tagLexer) term() (tagTerm, error) {
+	l.skipSpace()
+	start := l.pos
+	for l.pos < len(l.tag) && !strings.ContainsRune("=:,|' \t", rune(l.tag[l.pos])) {
+		l.pos++
+	}
+	term := tagTerm{name: l.tag[start:l.pos]}
+	if term.name == "" {
+		if l.peek() == 0 {
+			return tagTerm{}, l.errorf(l.pos, "expected rule name at end of tag")
+		}
+		return tagTerm{}, l.errorf(l.pos, "expected rule name, found %q", l.peek())
+	}
+
+	l.skipSpace()
+	if c := l.peek(); c == '=' || c == ':' {
+		l.pos++
+		l.skipSpace()
+		term.hasParam = true
+		var err error
+		if l.peek() == '\'' {
+			term.param, err = l.quoted()
+		} else {
+			term.param = l.unquoted()
+		}
+		if err != nil {
+			return tagTerm{}, err
+		}
+	}
+
+	if c := l.peek(); c != 0 && c != ',' && c != '|' {
+		return tagTerm{}, l.errorf(l.pos, "unexpected %q after %s", c, term.name)
+	}
+	term.raw = strings.TrimSpace(l.tag[start:l.pos])
+	return term, nil
+}
+
+// quoted reads a single quoted parameter and the whitespace after it.
+func (l *tagLexer) quoted() (string, error) {
+	open := l.pos
+	l.pos++
+	var b strings.Builder
+	for {
+		c := l.next()
+		switch c {
+		case 0:
+			return "", l.errorf(open, "unterminated quoted parameter")
+		case '\'':
+			l.skipSpace()
+			return b.String(), nil
+		case '\\':
+			if n := l.peek(); n == '\'' || n == '\\' {
+				c = l.next()
+			}
+		}
+		b.WriteByte(c)
+	}
+}
+
+// unquoted reads a parameter up to the next unescaped separator and trims
+// trailing whitespace.
+func (l *tagLexer) unquoted() string {
+	var b strings.Builder
+	end := 0
+	for {
+		c := l.peek()
+		if c == 0 || c == ',' || c == '|' {
+			return b.String()[:end]
+		}
+		l.pos++
+		if c == '\\' {
+			if n := l.peek(); n == ',' || n == '|' || n == '\'' || n == '\\' {
+				c = l.next()
+			}
+			b.WriteByte(c)
+			end = b.Len()
+			continue
+		}
+		b.WriteByte(c)
+		if !isSpace(c) {
+			end = b.Len()
+		}
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+// anyOf passes when at least one of its rules passes. It backs tags with
+// alternatives such as "ipv4|ipv6".
+type anyOf struct {
+	names []string
+	rules []rules.Rule
+}
+
+func (a anyOf) Validate(value interface{}) error {
+	return a.check(func(rule rules.Rule) error {
+		return rule.Validate(value)
+	})
+}
+
+// check runs fn on each alternative until one passes. When all of them fail,
+// the messages are joined and the individual errors are kept for errors.As.
+func (a anyOf) check(fn func(rules.Rule) error) error {
+	msgs := make([]string, 0, len(a.rules))
+	errs := make([]error, 0, len(a.rules))
+	for _, rule := range a.rules {
+		err := fn(rule)
+		if err == nil {
+			return nil
+		}
+		msgs = append(msgs, err.Error())
+		errs = append(errs, err)
+	}
+	return &rules.Error{
+		Rule:    strings.Join(a.names, "|"),
+		Message: strings.Join(msgs, " or "),
+		Err:     errors.Join(errs...),
+	}
+}
@@ -0,0 +1,186 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+func TestLexTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want [][]tagTerm
+	}{
+		{"single rule", "required", [][]tagTerm{
+			{{raw: "required", name: "required"}},
+		}},
+		{"parameters", "min=1,length:3:20", [][]tagTerm{
+			{{raw: "min=1", name: "min", param: "1", hasParam: true}},
+			{{raw: "length:3:20", name: "length", param: "3:20", hasParam: true}},
+		}},
+		{"alternatives", "ipv4|ipv6,required", [][]tagTerm{
+			{{raw: "ipv4", name: "ipv4"}, {raw: "ipv6", name: "ipv6"}},
+			{{raw: "required", name: "required"}},
+		}},
+		{"whitespace", " required , oneof = a b | ip ", [][]tagTerm{
+			{{raw: "required", name: "required"}},
+			{{raw: "oneof = a b", name: "oneof", param: "a b", hasParam: true}, {raw: "ip", name: "ip"}},
+		}},
+		{"quoted parameter", `oneof='a,b|c', contains='it\'s'`, [][]tagTerm{
+			{{raw: "oneof='a,b|c'", name: "oneof", param: "a,b|c", hasParam: true}},
+			{{raw: `contains='it\'s'`, name: "contains", param: "it's", hasParam: true}},
+		}},
+		{"escaped separators", `contains=a\,b\|c`, [][]tagTerm{
+			{{raw: `contains=a\,b\|c`, name: "contains", param: "a,b|c", hasParam: true}},
+		}},
+		{"regex keeps backslashes", `regex=^\d+=\w$`, [][]tagTerm{
+			{{raw: `regex=^\d+=\w$`, name: "regex", param: `^\d+=\w$`, hasParam: true}},
+		}},
+		{"empty parameter", "oneof=", [][]tagTerm{
+			{{raw: "oneof=", name: "oneof", hasParam: true}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lexTag(tt.tag)
+			if err != nil {
+				t.Fatalf("lexTag() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lexTag() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexTag_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		tag    string
+		column int
+	}{
+		{"trailing comma", "required,", 10},
+		{"empty alternative", "ipv4||ipv6", 6},
+		{"unterminated quote", "min=1,oneof='a b", 13},
+		{"text after quote", "oneof='a' b", 11},
+		{"missing separator", "required email", 10},
+		{"leading quote", "'required'", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lexTag(tt.tag)
+			var serr *TagSyntaxError
+			if !errors.As(err, &serr) {
+				t.Fatalf("lexTag() error = %v, want *TagSyntaxError", err)
+			}
+			if serr.Column != tt.column {
+				t.Errorf("Column = %d, want %d (%v)", serr.Column, tt.column, err)
+			}
+		})
+	}
+}
+
+func TestValidate_Alternatives(t *testing.T) {
+	type Server struct {
+		Addr string `validate:"required,ipv4|ipv6"`
+	}
+
+	v := New()
+	tests := []struct {
+		name    string
+		addr    string
+		wantErr bool
+	}{
+		{"ipv4", "192.168.0.1", false},
+		{"ipv6", "2001:db8::1", false},
+		{"neither", "localhost", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(Server{Addr: tt.addr})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	var verrs ValidationErrors
+	if !errors.As(v.Validate(Server{Addr: "localhost"}), &verrs) {
+		t.Fatal("Validate() expected ValidationErrors")
+	}
+	if verrs[0].Rule != "ipv4|ipv6" {
+		t.Errorf("Rule = %q, want ipv4|ipv6", verrs[0].Rule)
+	}
+	var ruleErr *rules.Error
+	if !errors.As(verrs[0], &ruleErr) {
+		t.Error("errors.As(*rules.Error) failed")
+	}
+}
+
+func TestValidate_QuotedParameters(t *testing.T) {
+	type Record struct {
+		Code  string   `validate:"regex='^[A-Z]{2,3}$'"`
+		Token string   `validate:"oneof='a=b,c'"`
+		Tags  []string `validate:"slice='ipv4|ipv6'"`
+	}
+
+	v := New()
+	tests := []struct {
+		name    string
+		value   Record
+		wantErr bool
+	}{
+		{"valid", Record{Code: "ABC", Token: "a=b,c", Tags: []string{"10.0.0.1", "::1"}}, false},
+		{"regex with comma", Record{Code: "ABCD", Token: "a=b,c", Tags: []string{}}, true},
+		{"value with separators", Record{Code: "AB", Token: "a=b", Tags: []string{}}, true},
+		{"slice alternatives", Record{Code: "AB", Token: "a=b,c", Tags: []string{"host"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_TagSyntaxError(t *testing.T) {
+	type Bad struct {
+		Name string `validate:"required,oneof='a b"`
+	}
+
+	v := New()
+	tests := []struct {
+		name   string
+		err    error
+		prefix string
+	}{
+		{"Validate", v.Validate(Bad{}), "Name: "},
+		{"Var", v.Var("a", "required,oneof='a b"), ""},
+		{"ValidateMap", v.ValidateMap(map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "required,oneof='a b"}), "schema name: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var serr *TagSyntaxError
+			if !errors.As(tt.err, &serr) {
+				t.Fatalf("error = %v, want a *TagSyntaxError", tt.err)
+			}
+			if !strings.HasPrefix(tt.err.Error(), tt.prefix) {
+				t.Errorf("error = %q, want the prefix %q", tt.err, tt.prefix)
+			}
+			if serr.Column != 16 || serr.Tag != "required,oneof='a b" {
+				t.Errorf("Column = %d, Tag = %q, want 16 in the field tag", serr.Column, serr.Tag)
+			}
+		})
+	}
+}
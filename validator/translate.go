@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"context"
+
+	"github.com/sgh370/goov/validator/i18n"
+	"github.com/sgh370/goov/validator/rules"
+)
+
+type localeKey struct{}
+
+// WithLocale returns a context that selects the locale of the error messages
+// of a ValidateCtx or ValidateAllCtx call, e.g. "de" or "fa-IR".
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// defaultTranslator holds only the embedded English catalog.
+var defaultTranslator = i18n.NewBundle()
+
+// SetTranslator selects the translator of error messages, usually an
+// *i18n.Bundle with the catalogs of the supported locales. Messages without a
+// translation keep their English text.
+func (v *Validator) SetTranslator(t i18n.Translator) {
+	v.translator = t
+}
+
+// SetLocale sets the locale used when the context of a call selects none.
+// It defaults to "en".
+func (v *Validator) SetLocale(locale string) {
+	v.locale = locale
+}
+
+func (v *Validator) localeOf(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey{}).(string); ok && locale != "" {
+		return locale
+	}
+	if v.locale != "" {
+		return v.locale
+	}
+	return "en"
+}
+
+// localize replaces the message of fe by its translation for the locale of
+// the call.
+func (w *walker) localize(fe *FieldError) {
+	if fe.Key == "" {
+		return
+	}
+	if msg, ok := w.v.translate(w.locale, fe.Key, fe.Params); ok {
+		fe.Message = msg
+	}
+}
+
+// translate renders key in locale. Parameters that are themselves rule
+// errors, such as the nested failure of an invalid map key, are translated
+// first.
+func (v *Validator) translate(locale, key string, params i18n.Params) (string, bool) {
+	t := v.translator
+	if t == nil {
+		t = defaultTranslator
+	}
+
+	var translated i18n.Params
+	for name, value := range params {
+		nested, ok := value.(*rules.Error)
+		if !ok || nested.Key == "" {
+			continue
+		}
+		msg, ok := v.translate(locale, nested.Key, nested.Params)
+		if !ok {
+			continue
+		}
+		if translated == nil {
+			translated = make(i18n.Params, len(params))
+			for k, val := range params {
+				translated[k] = val
+			}
+		}
+		translated[name] = msg
+	}
+	if translated != nil {
+		params = translated
+	}
+	return t.Translate(locale, key, params)
+}
@@ -0,0 +1,88 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sgh370/goov/validator/i18n"
+)
+
+type TranslateSignup struct {
+	Username string         `validate:"required,length=3:20"`
+	Password string         `validate:"password=1"`
+	Labels   map[string]int `validate:"dive,keys,length=2:5,endkeys"`
+	Website  string         `validate:"omitempty,url"`
+}
+
+func germanBundle(t *testing.T) *i18n.Bundle {
+	t.Helper()
+	de, err := i18n.ParseJSON("de", []byte(`{
+		"required": "Wert ist erforderlich",
+		"length.min": "Länge muss mindestens {min} betragen",
+		"password.min_length": "Passwort muss mindestens {min, plural, one {# Zeichen} other {# Zeichen}} lang sein",
+		"invalid_key": "ungültiger Schlüssel: {message}"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return i18n.NewBundle(de)
+}
+
+func firstMessage(t *testing.T, err error) *FieldError {
+	t.Helper()
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("error = %v, want ValidationErrors", err)
+	}
+	return verrs[0]
+}
+
+func TestValidateCtx_Locale(t *testing.T) {
+	v := New()
+	v.SetTranslator(germanBundle(t))
+
+	tests := []struct {
+		name    string
+		locale  string
+		value   TranslateSignup
+		wantKey string
+		wantMsg string
+	}{
+		{"default locale", "", TranslateSignup{}, "required", "value is required"},
+		{"german", "de", TranslateSignup{}, "required", "Wert ist erforderlich"},
+		{"german region", "de-DE", TranslateSignup{Username: "jo"}, "length.min", "Länge muss mindestens 3 betragen"},
+		{"german plural", "de", TranslateSignup{Username: "john"}, "password.min_length", "Passwort muss mindestens 1 Zeichen lang sein"},
+		{"nested map key", "de", TranslateSignup{Username: "john", Password: "Abcdefg1", Labels: map[string]int{"x": 1}}, "invalid_key", "ungültiger Schlüssel: Länge muss mindestens 2 betragen"},
+		{"english fallback", "de", TranslateSignup{Username: "john", Password: "Abcdefg1", Website: "::"}, "url.invalid", "invalid URL format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.locale != "" {
+				ctx = WithLocale(ctx, tt.locale)
+			}
+			fe := firstMessage(t, v.ValidateCtx(ctx, tt.value))
+			if fe.Key != tt.wantKey || fe.Message != tt.wantMsg {
+				t.Errorf("Key = %q, Message = %q, want %q, %q", fe.Key, fe.Message, tt.wantKey, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	v := New()
+	v.SetTranslator(germanBundle(t))
+	v.SetLocale("de")
+
+	if fe := firstMessage(t, v.Validate(TranslateSignup{})); fe.Message != "Wert ist erforderlich" {
+		t.Errorf("Message = %q, want the German message", fe.Message)
+	}
+
+	// The locale of the call takes precedence over the default.
+	ctx := WithLocale(context.Background(), "en")
+	if fe := firstMessage(t, v.ValidateCtx(ctx, TranslateSignup{})); fe.Message != "value is required" {
+		t.Errorf("Message = %q, want the English message", fe.Message)
+	}
+}
@@ -1,279 +1,191 @@
 package validator
 
 import (
+	"context"
 	"fmt"
 	"reflect"
-	"strconv"
-	"strings"
+	"sync"
 
+	"github.com/sgh370/goov/validator/i18n"
 	"github.com/sgh370/goov/validator/rules"
 )
 
 type Validator struct {
-	rules map[string]rules.Rule
+	rules       map[string]rules.Rule
+	factories   map[string]RuleFactory
+	structRules map[reflect.Type][]StructRule
+
+	pathFormat    PathFormat
+	fieldNameFunc FieldNameFunc
+	maxErrors     int
+
+	translator i18n.Translator
+	locale     string
+	messages   map[string]*i18n.Template
+
+	// plans caches the compiled *structPlan of each struct type and set of
+	// groups, and the *varPlan of each Var tag.
+	plans sync.Map
 }
 
+// New returns a validator with the default rule set registered.
 func New() *Validator {
+	v := NewEmpty()
+	v.registerDefaults()
+	return v
+}
+
+// NewEmpty returns a validator without any registered rules or factories.
+func NewEmpty() *Validator {
 	return &Validator{
-		rules: make(map[string]rules.Rule),
+		rules:       make(map[string]rules.Rule),
+		factories:   make(map[string]RuleFactory),
+		structRules: make(map[reflect.Type][]StructRule),
+		messages:    make(map[string]*i18n.Template),
 	}
 }
 
 func (v *Validator) AddRule(name string, rule rules.Rule) {
 	v.rules[name] = rule
+	v.resetPlans()
 }
 
-func (v *Validator) Validate(value interface{}) error {
-	if value == nil {
-		return fmt.Errorf("value is nil")
-	}
-
-	val := reflect.ValueOf(value)
-	if val.Kind() == reflect.Ptr {
-		if val.IsNil() {
-			return fmt.Errorf("value is nil")
-		}
-		val = val.Elem()
-	}
-
-	if val.Kind() != reflect.Struct {
-		return fmt.Errorf("value must be a struct or pointer to struct")
-	}
-
-	return v.validateStruct(val)
+// SetMaxErrors caps the number of errors ValidateAll collects before it
+// stops walking. Zero, the default, collects every error.
+func (v *Validator) SetMaxErrors(n int) {
+	v.maxErrors = n
 }
 
-func (v *Validator) validateStruct(val reflect.Value) error {
-	if val.Kind() == reflect.Ptr {
-		if val.IsNil() {
-			return nil
-		}
-		val = val.Elem()
-	}
-
-	if val.Kind() != reflect.Struct {
-		return nil
-	}
-
-	valType := val.Type()
-	var parent interface{}
-	if val.CanAddr() {
-		parent = val.Addr().Interface()
-	}
-
-	for i := 0; i < val.NumField(); i++ {
-		field := val.Field(i)
-		fieldType := valType.Field(i)
-
-		if !fieldType.IsExported() {
-			continue
-		}
-
-		tag := fieldType.Tag.Get("validate")
-		if tag == "" {
-			continue
-		}
-
-		// Handle nested struct validation
-		if field.Kind() == reflect.Ptr {
-			if field.IsNil() {
-				if err := v.validateField(field, tag, parent); err != nil {
-					return fmt.Errorf("%s: %v", fieldType.Name, err)
-				}
-				continue
-			}
-			field = field.Elem()
-		}
-
-		if field.Kind() == reflect.Struct {
-			if err := v.validateStruct(field); err != nil {
-				return fmt.Errorf("%s: %v", fieldType.Name, err)
-			}
-		}
-
-		if err := v.validateField(field, tag, parent); err != nil {
-			return fmt.Errorf("%s: %v", fieldType.Name, err)
-		}
-	}
-
-	return nil
+// Validate checks value and stops at the first failing field. Failures are
+// returned as ValidationErrors.
+func (v *Validator) Validate(value interface{}) error {
+	return v.ValidateCtx(context.Background(), value)
 }
 
-func (v *Validator) validateField(field reflect.Value, tag string, parent interface{}) error {
-	if tag == "" {
-		return nil
+// ValidateCtx is like Validate but bounds the walk by ctx. Rules that
+// implement rules.CtxRule receive ctx. Once ctx is done the walk stops and an
+// *AbortError wrapping ctx.Err() is returned.
+func (v *Validator) ValidateCtx(ctx context.Context, value interface{}) error {
+	verrs, err := v.validate(ctx, value, false, nil)
+	if err != nil {
+		return err
 	}
-
-	for _, rule := range strings.Split(tag, ",") {
-		parts := strings.Split(rule, "=")
-		ruleName := parts[0]
-		var ruleValue string
-		if len(parts) > 1 {
-			ruleValue = parts[1]
-		}
-
-		switch ruleName {
-		case "slice":
-			if err := v.validateSlice(field, rule); err != nil {
-				return err
-			}
-		case "min":
-			val, err := strconv.ParseFloat(ruleValue, 64)
-			if err != nil {
-				return fmt.Errorf("invalid min value: %s", ruleValue)
-			}
-			minRule := rules.Min{Value: val}
-			if err := minRule.Validate(field.Interface()); err != nil {
-				return err
-			}
-		default:
-			rule := v.rules[ruleName]
-			if rule == nil {
-				return fmt.Errorf("unknown validation rule: %s", ruleName)
-			}
-			if setter, ok := rule.(interface{ SetParent(interface{}) }); ok {
-				setter.SetParent(parent)
-			}
-			if err := rule.Validate(field.Interface()); err != nil {
-				return err
-			}
-		}
+	if len(verrs) > 0 {
+		return verrs
 	}
-
 	return nil
 }
 
-func (v *Validator) validateSlice(field reflect.Value, tag string) error {
-	if tag == "" {
-		return nil
-	}
+// ValidateAll checks value, including nested structs and slices, and returns
+// every failing field. Each field reports at most one error.
+func (v *Validator) ValidateAll(value interface{}) []error {
+	return v.ValidateAllCtx(context.Background(), value)
+}
 
-	parts := strings.Split(tag, "=")
-	if len(parts) != 2 {
-		return fmt.Errorf("invalid slice validation format: %s", tag)
-	}
+// ValidateAllCtx is like ValidateAll but bounds the walk by ctx. Once ctx is
+// done no further fields are checked and an *AbortError is appended.
+func (v *Validator) ValidateAllCtx(ctx context.Context, value interface{}) []error {
+	verrs, err := v.validate(ctx, value, true, nil)
 
-	rule := v.rules[parts[1]]
-	if rule == nil {
-		return fmt.Errorf("unknown validation rule: %s", parts[1])
-	}
-
-	if field.Kind() != reflect.Slice {
-		return fmt.Errorf("field is not a slice")
+	var errors []error
+	for _, fe := range verrs {
+		errors = append(errors, fe)
 	}
-
-	if field.IsNil() {
-		return fmt.Errorf("slice is nil")
+	if err != nil {
+		errors = append(errors, err)
 	}
-
-	for i := 0; i < field.Len(); i++ {
-		item := field.Index(i)
-		if item.Kind() == reflect.Ptr && !item.IsNil() {
-			item = item.Elem()
-		}
-
-		if item.Kind() == reflect.Struct {
-			if err := v.validateStruct(item); err != nil {
-				return fmt.Errorf("item at index %d: %v", i, err)
-			}
-		} else {
-			if err := rule.Validate(item.Interface()); err != nil {
-				return fmt.Errorf("item at index %d: %v", i, err)
-			}
-		}
-	}
-
-	return nil
+	return errors
 }
 
-func (v *Validator) ValidateAll(value interface{}) []error {
-	var errors []error
-
+// validate walks value. A non-nil partial limits the walk to some fields.
+func (v *Validator) validate(ctx context.Context, value interface{}, all bool, p *partial) (ValidationErrors, error) {
 	if value == nil {
-		return append(errors, fmt.Errorf("value is nil"))
+		return nil, fmt.Errorf("value is nil")
 	}
 
 	val := reflect.ValueOf(value)
 	if val.Kind() == reflect.Ptr {
 		if val.IsNil() {
-			return append(errors, fmt.Errorf("value is nil"))
+			return nil, fmt.Errorf("value is nil")
 		}
 		val = val.Elem()
 	}
 
 	if val.Kind() != reflect.Struct {
-		return append(errors, fmt.Errorf("value must be a struct or pointer to struct"))
+		return nil, fmt.Errorf("value must be a struct or pointer to struct")
 	}
 
-	typ := val.Type()
-	for i := 0; i < val.NumField(); i++ {
-		field := val.Field(i)
-		fieldType := typ.Field(i)
-
-		if !fieldType.IsExported() {
-			continue
-		}
-
-		tag := fieldType.Tag.Get("validate")
-		if tag == "" {
-			continue
+	var filter *fieldFilter
+	if p != nil {
+		var err error
+		if filter, err = v.newFieldFilter(val.Type(), p); err != nil {
+			return nil, err
 		}
+	}
 
-		if field.Kind() == reflect.Ptr {
-			if field.IsNil() {
-				for _, ruleName := range strings.Split(tag, ",") {
-					rule, ok := v.rules[ruleName]
-					if !ok {
-						errors = append(errors, fmt.Errorf("%s: unknown validation rule: %s", fieldType.Name, ruleName))
-						continue
-					}
+	w := v.newWalker(ctx, value, all)
+	defer w.release()
+	w.filter = filter
+	return w.run(addressable(val))
+}
 
-					if setter, ok := rule.(interface{ SetParent(interface{}) }); ok {
-						setter.SetParent(val.Addr().Interface())
-					}
+// addressable returns val itself when it can be addressed, or an addressable
+// copy of it otherwise, so that context rules always receive a parent even
+// when a struct is passed by value.
+func addressable(val reflect.Value) reflect.Value {
+	if val.CanAddr() {
+		return val
+	}
+	ptr := reflect.New(val.Type())
+	ptr.Elem().Set(val)
+	return ptr.Elem()
+}
 
-					if err := rule.Validate(nil); err != nil {
-						errors = append(errors, fmt.Errorf("%s: %v", fieldType.Name, err))
-					}
-				}
-				continue
-			}
-			field = field.Elem()
-		}
+// Pattern combines rules that pass when all of them pass, in order. A
+// pattern registered under a tag name with AddRule or RegisterAlias expands
+// into its rules when a tag using it is compiled, so failures report the
+// failing rule of the pattern in FieldError.Rule and the tag name in
+// FieldError.Alias.
+type Pattern struct {
+	rules []tagRule
+}
 
-		if field.Kind() == reflect.Struct {
-			if err := v.validateStruct(field); err != nil {
-				errors = append(errors, fmt.Errorf("%s: %v", fieldType.Name, err))
-			}
-			continue
+func NewPattern(rs ...rules.Rule) *Pattern {
+	p := &Pattern{rules: make([]tagRule, len(rs))}
+	for i, rule := range rs {
+		p.rules[i] = tagRule{rule: rule}
+		if named, ok := rule.(interface{ Name() string }); ok {
+			p.rules[i].name = named.Name()
 		}
+	}
+	return p
+}
 
-		if field.Kind() == reflect.Slice {
-			if err := v.validateSlice(field, tag); err != nil {
-				errors = append(errors, fmt.Errorf("%s: %v", fieldType.Name, err))
+func (p *Pattern) Validate(value interface{}) error {
+	for _, r := range p.rules {
+		if r.rule == nil {
+			if omitted(r.name, reflect.ValueOf(&value).Elem()) {
+				return nil
 			}
 			continue
 		}
-
-		if err := v.validateField(field, tag, val.Addr().Interface()); err != nil {
-			errors = append(errors, fmt.Errorf("%s: %v", fieldType.Name, err))
+		if err := r.rule.Validate(value); err != nil {
+			return err
 		}
 	}
-
-	return errors
-}
-
-type Pattern struct {
-	rules []rules.Rule
-}
-
-func NewPattern(rules ...rules.Rule) *Pattern {
-	return &Pattern{rules: rules}
+	return nil
 }
 
-func (p *Pattern) Validate(value interface{}) error {
-	for _, rule := range p.rules {
-		if err := rule.Validate(value); err != nil {
+// ValidateContext passes ctx on to the context rules of the pattern.
+func (p *Pattern) ValidateContext(ctx rules.ValidationContext, value interface{}) error {
+	for _, r := range p.rules {
+		if r.rule == nil {
+			if omitted(r.name, reflect.ValueOf(&value).Elem()) {
+				return nil
+			}
+			continue
+		}
+		if err := rules.Apply(ctx, r.rule, value); err != nil {
 			return err
 		}
 	}
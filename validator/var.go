@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+)
+
+// varKey identifies the compiled rules of a Var tag in the plan cache.
+type varKey string
+
+type varPlan struct {
+	rules []tagRule
+	err   error
+}
+
+// Var validates a single value, such as a query parameter, against a tag
+// written as in struct tags:
+//
+//	err := v.Var(email, "required,email")
+//
+// Failures are returned as ValidationErrors holding one *FieldError with an
+// empty Namespace. Structs passed to Var have their fields validated too, and
+// an empty tag accepts any value.
+func (v *Validator) Var(value interface{}, tag string) error {
+	return v.VarCtx(context.Background(), value, tag)
+}
+
+// VarCtx is like Var but bounds the validation by ctx, as ValidateCtx does.
+func (v *Validator) VarCtx(ctx context.Context, value interface{}, tag string) error {
+	return v.validateVar(ctx, value, nil, tag)
+}
+
+// VarWithField validates value against tag with other in place of the
+// parent struct, so that cross-field rules can compare the two values:
+//
+//	err := v.VarWithField(confirm, password, "eqfield")
+//
+// Context rules receive other as rules.ValidationContext.Parent.
+func (v *Validator) VarWithField(value, other interface{}, tag string) error {
+	return v.VarWithFieldCtx(context.Background(), value, other, tag)
+}
+
+// VarWithFieldCtx is like VarWithField but bounds the validation by ctx.
+func (v *Validator) VarWithFieldCtx(ctx context.Context, value, other interface{}, tag string) error {
+	return v.validateVar(ctx, value, other, tag)
+}
+
+func (v *Validator) validateVar(ctx context.Context, value, parent interface{}, tag string) error {
+	plan := v.varPlanFor(tag)
+	if plan.err != nil {
+		return plan.err
+	}
+
+	w := v.newWalker(ctx, value, false)
+	defer w.release()
+
+	// The value is validated through an interface so that nil values reach
+	// rules such as required instead of an invalid reflect.Value.
+	err := w.validateValue(reflect.ValueOf(&value).Elem(), plan.rules, parent)
+	if err != nil && err != errStop {
+		return err
+	}
+	if len(w.errs) > 0 {
+		return w.errs
+	}
+	return nil
+}
+
+// varPlanFor returns the compiled rules of a Var tag, parsing it on first
+// use. The tag serves values of any type and parent, so its expr rules are
+// only checked for what holds without types, such as yielding a boolean.
+func (v *Validator) varPlanFor(tag string) *varPlan {
+	if plan, ok := v.plans.Load(varKey(tag)); ok {
+		return plan.(*varPlan)
+	}
+	var rules []tagRule
+	var err error
+	if tag != "" {
+		rules, err = v.parseTag(tag)
+		if err == nil {
+			err = checkExprs(rules, nil, nil)
+		}
+	}
+	plan, _ := v.plans.LoadOrStore(varKey(tag), &varPlan{rules: rules, err: err})
+	return plan.(*varPlan)
+}
@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sgh370/goov/validator/rules"
+)
+
+func TestVar(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		tag     string
+		wantErr bool
+		rule    string
+	}{
+		{"valid email", "john@example.com", "required,email", false, ""},
+		{"invalid email", "john", "required,email", true, "email"},
+		{"empty", "", "required,email", true, "required"},
+		{"nil", nil, "required", true, "required"},
+		{"nil pointer", (*string)(nil), "omitnil,email", false, ""},
+		{"pointer", func() *string { s := "john@example.com"; return &s }(), "email", false, ""},
+		{"omitempty", "", "omitempty,email", false, ""},
+		{"factory", 5, "range=1:3", true, "range"},
+		{"alternatives", "10.0.0.1", "email|ip", false, ""},
+		{"dive", []string{"a", ""}, "dive,required", true, "required"},
+		{"struct fields", NamesAddress{}, "required", true, "required"},
+		{"no rules", "anything", "", false, ""},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Var(tt.value, tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Var() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var verrs ValidationErrors
+			if !errors.As(err, &verrs) || len(verrs) != 1 {
+				t.Fatalf("Var() error = %v, want one field error", err)
+			}
+			if verrs[0].Rule != tt.rule {
+				t.Errorf("Rule = %q, want %q", verrs[0].Rule, tt.rule)
+			}
+		})
+	}
+}
+
+func TestVar_Errors(t *testing.T) {
+	v := New()
+
+	err := v.Var("john", "required,email")
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Namespace != "" || fe.Key != "email.invalid" || fe.Value != "john" {
+		t.Errorf("Var() error = %#v", fe)
+	}
+	if err.Error() != fe.Message {
+		t.Errorf("Error() = %q, want the bare message", err.Error())
+	}
+
+	if err := v.Var("john", "unknown"); err == nil || errors.As(err, &fe) {
+		t.Errorf("Var() error = %v, want a tag error", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var abort *AbortError
+	if err := v.VarCtx(ctx, NamesAddress{}, "required"); !errors.As(err, &abort) {
+		t.Errorf("VarCtx() error = %v, want an *AbortError", err)
+	}
+}
+
+func TestVarWithField(t *testing.T) {
+	v := New()
+	v.AddRule("same", rules.CrossField{ValidateFn: func(other, value interface{}) error {
+		if other != value {
+			return fmt.Errorf("values differ")
+		}
+		return nil
+	}})
+
+	if err := v.VarWithField("secret", "secret", "required,same"); err != nil {
+		t.Errorf("VarWithField() unexpected error = %v", err)
+	}
+	if err := v.VarWithField("secret", "other", "required,same"); err == nil {
+		t.Error("VarWithField() expected an error for different values")
+	}
+}
@@ -0,0 +1,418 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/sgh370/goov/validator/i18n"
+	"github.com/sgh370/goov/validator/rules"
+)
+
+// walker holds the state of a single validation call. Walkers are pooled so
+// that validating a value does not allocate the path stack.
+//
+// The same walk serves both Validate and ValidateAll: field errors are passed
+// to report, which decides whether the walk stops (fail-fast, or the error cap
+// was reached) or continues collecting.
+type walker struct {
+	v    *Validator
+	ctx  context.Context
+	root interface{}
+	path []PathSegment
+
+	all  bool
+	max  int
+	errs ValidationErrors
+
+	// inKey is set while the keys of a map are validated.
+	inKey bool
+	// label is the label of the innermost struct field being validated.
+	label string
+	// message is the msg tag of the innermost struct field being validated.
+	message *i18n.Template
+	// locale selects the language of error messages.
+	locale string
+	// groups are the active validation groups; see WithGroups.
+	groups string
+	// filter selects the fields of the current struct that are validated;
+	// see ValidateFields.
+	filter *fieldFilter
+}
+
+// errStop ends a walk once no further errors are wanted. It never reaches
+// callers.
+var errStop = errors.New("stop validation")
+
+var walkerPool = sync.Pool{
+	New: func() interface{} {
+		return &walker{path: make([]PathSegment, 0, 8)}
+	},
+}
+
+func (v *Validator) newWalker(ctx context.Context, root interface{}, all bool) *walker {
+	w := walkerPool.Get().(*walker)
+	w.v = v
+	w.ctx = ctx
+	w.locale = v.localeOf(ctx)
+	w.groups = groupsOf(ctx)
+	w.root = root
+	w.all = all
+	w.max = v.maxErrors
+	return w
+}
+
+// release returns w to the pool. Every per-call field is cleared, so that a
+// walk abandoned by a panicking rule does not leak into the next call.
+func (w *walker) release() {
+	*w = walker{path: w.path[:0]}
+	walkerPool.Put(w)
+}
+
+// run walks val and returns the collected field errors along with any error
+// that ended the walk early, such as a malformed tag or an *AbortError.
+func (w *walker) run(val reflect.Value) (ValidationErrors, error) {
+	err := w.validateStruct(val)
+	if err == errStop {
+		err = nil
+	}
+	return w.errs, err
+}
+
+// report records a field error and returns errStop when the walk must end.
+func (w *walker) report(fe *FieldError) error {
+	w.errs = append(w.errs, fe)
+	if !w.all || (w.max > 0 && len(w.errs) >= w.max) {
+		return errStop
+	}
+	return nil
+}
+
+func (w *walker) push(seg PathSegment) {
+	w.path = append(w.path, seg)
+}
+
+func (w *walker) pop() {
+	w.path = w.path[:len(w.path)-1]
+}
+
+func (w *walker) validateStruct(val reflect.Value) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	plan := w.v.planFor(val.Type(), w.groups)
+	if plan.err != nil {
+		return plan.err
+	}
+
+	var parent interface{}
+	if val.CanAddr() {
+		parent = val.Addr().Interface()
+	}
+
+	failed := len(w.errs)
+	filter := w.filter
+	for i := range plan.fields {
+		if err := w.aborted(); err != nil {
+			return err
+		}
+
+		fp := &plan.fields[i]
+		var visit bool
+		if w.filter, visit = filter.visit(val.Type().Field(fp.index).Name); !visit {
+			continue
+		}
+		if w.filter != nil && !w.filter.except {
+			// Only the selected fields beneath this one are validated.
+			fp = &fieldPlan{index: fp.index, name: fp.name, label: fp.label, elems: hasStructElems(val.Type().Field(fp.index).Type)}
+		}
+
+		label, message := w.label, w.message
+		w.label, w.message = fp.label, fp.message
+		w.push(PathSegment{Field: fp.name})
+		err := w.validateStructField(val.Field(fp.index), fp, parent)
+		w.pop()
+		w.label, w.message = label, message
+		if err != nil {
+			w.filter = filter
+			return err
+		}
+	}
+	w.filter = filter
+
+	// Struct-level checks only run once every field of the struct passed,
+	// and not when some of them were left out.
+	if len(plan.hooks) == 0 || len(w.errs) > failed || filter != nil {
+		return nil
+	}
+	return w.validateStructLevel(val, plan)
+}
+
+func (w *walker) validateStructField(field reflect.Value, fp *fieldPlan, parent interface{}) error {
+	if err := w.validateValue(field, fp.rules, parent); err != nil {
+		return nestedError(fp.name, err)
+	}
+	if !fp.elems {
+		return nil
+	}
+
+	// Struct elements of collections are walked as if the tag ended in dive.
+	if field.Kind() == reflect.Ptr {
+		field = field.Elem()
+	}
+	if err := w.validateDive(field, tagRule{name: "dive"}, parent); err != nil {
+		return nestedError(fp.name, err)
+	}
+	return nil
+}
+
+// validateValue validates a struct field or a collection element: the fields
+// of a nested struct first, then the rules of the tag.
+func (w *walker) validateValue(field reflect.Value, tag []tagRule, parent interface{}) error {
+	// A leading omitempty or omitnil also skips the fields of a nested struct.
+	if len(tag) > 0 && omitted(tag[0].name, field) {
+		return nil
+	}
+
+	if field.Kind() == reflect.Interface && !field.IsNil() {
+		field = field.Elem()
+	}
+
+	// Handle nested struct validation
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return w.validateField(field, tag, parent)
+		}
+		field = field.Elem()
+	}
+
+	if field.Kind() == reflect.Struct {
+		if err := w.validateStruct(field); err != nil {
+			return err
+		}
+	}
+
+	return w.validateField(field, tag, parent)
+}
+
+// validateField runs the rules of a field in order and stops at the first
+// failing rule, so each field reports at most one error in either mode.
+func (w *walker) validateField(field reflect.Value, tag []tagRule, parent interface{}) error {
+	optional := false
+	for _, r := range tag {
+		_, presence := r.rule.(rules.Presence)
+		if optional && !presence {
+			// An empty value that passed its presence rules is optional.
+			return nil
+		}
+		if r.name == "dive" {
+			return w.validateDive(field, r, parent)
+		}
+		if r.rule == nil {
+			if omitted(r.name, field) {
+				return nil
+			}
+			continue
+		}
+		if r.name == "slice" {
+			failed := len(w.errs)
+			if err := w.validateSlice(field, r); err != nil || len(w.errs) > failed {
+				return err
+			}
+			continue
+		}
+
+		if err := w.apply(r.rule, field, parent); err != nil {
+			if abort := w.aborted(); abort != nil {
+				return abort
+			}
+			return w.report(w.fieldError(r, field, err))
+		}
+		if presence && (!field.IsValid() || rules.IsEmpty(field.Interface())) {
+			optional = true
+		}
+	}
+
+	return nil
+}
+
+// validateDive applies the element rules of a dive to every element of a
+// slice, array or map, and its key rules to every map key. Maps are visited in
+// the order of rules.SortedKeys. Each element reports at most one error.
+func (w *walker) validateDive(field reflect.Value, r tagRule, parent interface{}) error {
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array:
+		if r.keys != nil {
+			return w.report(w.fieldError(r, field, rules.NewError("keys", "keys.type", nil)))
+		}
+		for i := 0; i < field.Len(); i++ {
+			if err := w.aborted(); err != nil {
+				return err
+			}
+			w.push(PathSegment{Key: i})
+			err := w.validateValue(field.Index(i), r.elem, parent)
+			w.pop()
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, key := range rules.SortedKeys(field) {
+			if err := w.aborted(); err != nil {
+				return err
+			}
+			w.push(PathSegment{Key: key.Interface()})
+			err := w.validateEntry(key, field.MapIndex(key), r, parent)
+			w.pop()
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Ptr, reflect.Interface, reflect.Invalid:
+		// A nil collection has no elements to dive into.
+	default:
+		return w.report(w.fieldError(r, field, rules.NewError("dive", "dive.type", nil)))
+	}
+	return nil
+}
+
+// validateEntry validates a map key with the key rules of a dive and, when
+// the key is valid, its value with the element rules.
+func (w *walker) validateEntry(key, value reflect.Value, r tagRule, parent interface{}) error {
+	if r.keys != nil {
+		failed := len(w.errs)
+		if err := w.validateKey(key, r.keys, parent); err != nil || len(w.errs) > failed {
+			return err
+		}
+	}
+	return w.validateValue(value, r.elem, parent)
+}
+
+// validateKey validates a map key with the key rules of a dive.
+func (w *walker) validateKey(key reflect.Value, tag []tagRule, parent interface{}) error {
+	w.inKey = true
+	defer func() { w.inKey = false }()
+	return w.validateValue(key, tag, parent)
+}
+
+// omitted reports whether the omitempty or omitnil modifier named by
+// modifier skips field. omitempty skips nil values and values that point to a
+// zero value; omitnil skips only nil pointers, interfaces, maps and slices.
+func omitted(modifier string, field reflect.Value) bool {
+	switch modifier {
+	case "omitnil":
+		switch field.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+			return field.IsNil()
+		}
+		return !field.IsValid()
+	case "omitempty":
+		for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+			if field.IsNil() {
+				return true
+			}
+			field = field.Elem()
+		}
+		return !field.IsValid() || field.IsZero()
+	}
+	return false
+}
+
+func (w *walker) validateSlice(field reflect.Value, r tagRule) error {
+	if field.Kind() != reflect.Slice {
+		return w.report(w.fieldError(r, field, rules.NewError("slice", "slice.type", nil)))
+	}
+
+	if field.IsNil() {
+		return w.report(w.fieldError(r, field, rules.NewError("slice", "slice.nil", nil)))
+	}
+
+	for i := 0; i < field.Len(); i++ {
+		if err := w.aborted(); err != nil {
+			return err
+		}
+
+		item := field.Index(i)
+		if item.Kind() == reflect.Ptr && !item.IsNil() {
+			item = item.Elem()
+		}
+
+		w.push(PathSegment{Key: i})
+		var err error
+		if item.Kind() == reflect.Struct {
+			if err = w.validateStruct(item); err != nil {
+				err = nestedError(fieldName(w.path), err)
+			}
+		} else if err = r.rule.Validate(item.Interface()); err != nil {
+			err = w.report(w.fieldError(r, item, err))
+		}
+		w.pop()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// aborted reports an *AbortError once the context of the call is done.
+func (w *walker) aborted() error {
+	if err := w.ctx.Err(); err != nil {
+		return &AbortError{Err: err}
+	}
+	return nil
+}
+
+// apply runs rule on field. Context rules receive the parent through a
+// per-call context; the rule instance itself is never modified.
+func (w *walker) apply(rule rules.Rule, field reflect.Value, parent interface{}) error {
+	if any, ok := rule.(anyOf); ok {
+		return any.check(func(alt rules.Rule) error {
+			return w.apply(alt, field, parent)
+		})
+	}
+	if cr, ok := rule.(rules.ContextRule); ok {
+		return cr.ValidateContext(w.context(parent), field.Interface())
+	}
+	if cr, ok := rule.(rules.CtxRule); ok {
+		return cr.ValidateCtx(w.ctx, field.Interface())
+	}
+
+	// Rules written before ContextRule existed still receive their parent
+	// through SetParent.
+	if setter, ok := rule.(interface{ SetParent(interface{}) }); ok {
+		setter.SetParent(parent)
+	}
+	return rule.Validate(field.Interface())
+}
+
+func (w *walker) context(parent interface{}) rules.ValidationContext {
+	return rules.ValidationContext{
+		Parent:  parent,
+		Root:    w.root,
+		Path:    dottedPath(w.path),
+		Context: w.ctx,
+	}
+}
+
+func (w *walker) fieldError(r tagRule, value reflect.Value, err error) *FieldError {
+	if w.inKey {
+		invalid := rules.NewError(r.name, "invalid_key", rules.Params{"message": err})
+		invalid.Err = err
+		err = invalid
+	}
+	fe := w.v.newFieldError(appendPath(nil, w.path...), r, value, err)
+	fe.Label = w.label
+	w.localize(fe)
+	w.customize(fe, r.message, w.message)
+	return fe
+}